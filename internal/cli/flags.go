@@ -27,4 +27,6 @@ func flagGeneratorArgs(fs *pflag.FlagSet, g *gengoargs.GeneratorArgs) {
 
 func flagCustomGeneratorArgs(fs *pflag.FlagSet, customArgs *generators.CustomArgs) {
 	fs.StringSliceVar(&customArgs.BoundingDirs, "bounding-dirs", customArgs.BoundingDirs, "specify directories to bound the generation")
+	fs.StringVar(&customArgs.MemberConfig, "member-config", customArgs.MemberConfig, "YAML file of per-type member include and exclude rules extending the default profile.")
+	fs.StringVar(&customArgs.TemplateDir, "template-dir", customArgs.TemplateDir, "Directory of <snippet>.tmpl files overriding the built-in snippet templates.")
 }
@@ -8,6 +8,8 @@ import (
 
 	"github.com/kanopy-platform/code-generator/pkg/generators"
 	"github.com/kanopy-platform/code-generator/pkg/generators/builder"
+	"github.com/kanopy-platform/code-generator/pkg/generators/members"
+	"github.com/kanopy-platform/code-generator/pkg/generators/snippets"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -49,6 +51,7 @@ func NewRootCommand(opts ...func(*rootCommand)) *cobra.Command {
 	}
 
 	rootCommand.setupFlags(cmd)
+	cmd.AddCommand(newScaffoldCommand())
 
 	return cmd
 }
@@ -64,8 +67,9 @@ func (r *rootCommand) setupFlags(cmd *cobra.Command) {
 }
 
 func (r *rootCommand) prerun(cmd *cobra.Command, args []string) error {
-
-	if err := viper.BindPFlags(cmd.PersistentFlags()); err != nil {
+	// Flags() includes the persistent flags inherited from the root command, so
+	// subcommands see --log-level too.
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
 		return err
 	}
 
@@ -85,7 +89,26 @@ func (r *rootCommand) runE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	g := generators.New(&builder.BuilderPatternGeneratorFactory{OutputFileBaseName: r.GeneratorArgs.OutputFileBaseName},
+	factory := &builder.BuilderPatternGeneratorFactory{OutputFileBaseName: r.GeneratorArgs.OutputFileBaseName}
+	if customArgs, ok := r.GeneratorArgs.CustomArgs.(*generators.CustomArgs); ok {
+		if customArgs.TemplateDir != "" {
+			templates, err := snippets.LoadTemplates(customArgs.TemplateDir)
+			if err != nil {
+				return err
+			}
+			factory.Templates = templates
+		}
+
+		if customArgs.MemberConfig != "" {
+			config, err := members.LoadConfig(customArgs.MemberConfig)
+			if err != nil {
+				return err
+			}
+			factory.Members = config
+		}
+	}
+
+	g := generators.New(factory,
 		generators.WithBoilerplate(strings.Join(headerLines, "\n")), generators.WithPackageRoot(mod))
 	return r.GeneratorArgs.Execute(
 		generators.NameSystems(),
@@ -14,11 +14,11 @@ func TestRootCommandGeneratorArgs(t *testing.T) {
 		want *gengoargs.GeneratorArgs
 	}{
 		{
-			args: []string{"--bounding-dirs=dir", "--input-dirs=test", "--output-base=./src", "--output-package=pkg", "--output-file-base=zz-gen", "--go-header-file=myfile", "--verify-only", "--include-test-files", "--build-tag=abc", "--trim-path-prefix=src"},
+			args: []string{"--bounding-dirs=dir", "--template-dir=templates", "--member-config=members.yaml", "--input-dirs=test", "--output-base=./src", "--output-package=pkg", "--output-file-base=zz-gen", "--go-header-file=myfile", "--verify-only", "--include-test-files", "--build-tag=abc", "--trim-path-prefix=src"},
 			want: func() *gengoargs.GeneratorArgs {
 				g := gengoargs.Default()
 
-				g.CustomArgs = &generators.CustomArgs{BoundingDirs: []string{"dir"}}
+				g.CustomArgs = &generators.CustomArgs{BoundingDirs: []string{"dir"}, TemplateDir: "templates", MemberConfig: "members.yaml"}
 				g.InputDirs = []string{"test"}
 				g.OutputBase = "./src"
 				g.OutputPackagePath = "pkg"
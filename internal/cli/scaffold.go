@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/scaffold"
+	"github.com/spf13/cobra"
+)
+
+type scaffoldCommand struct {
+	ConfigPath string
+	OutputDir  string
+	Force      bool
+}
+
+func newScaffoldCommand() *cobra.Command {
+	scaffoldCommand := &scaffoldCommand{}
+
+	cmd := &cobra.Command{
+		Use:   "scaffold",
+		Short: "Scaffold wrapper type declarations of upstream types from a config file",
+		Args:  cobra.NoArgs,
+		RunE:  scaffoldCommand.runE,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&scaffoldCommand.ConfigPath, "config", "c", "", "YAML file listing the upstream types to wrap.")
+	flags.StringVarP(&scaffoldCommand.OutputDir, "output-dir", "d", ".", "Directory of the package to write doc.go and types.go into.")
+	flags.BoolVar(&scaffoldCommand.Force, "force", false, "Overwrite existing files.")
+	_ = cmd.MarkFlagRequired("config")
+
+	return cmd
+}
+
+func (s *scaffoldCommand) runE(cmd *cobra.Command, args []string) error {
+	config, err := scaffold.LoadConfig(s.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	return scaffold.Write(config, s.OutputDir, s.Force)
+}
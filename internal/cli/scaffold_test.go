@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaffoldCommand(t *testing.T) {
+	config := "../../pkg/scaffold/testdata/scaffold.yaml"
+
+	tests := []struct {
+		args []string
+	}{
+		{args: []string{"scaffold", "-c", config}},
+		{args: []string{"scaffold", "-c", config, "-v", "debug"}},
+		{args: []string{"--log-level", "warn", "scaffold", "-c", config}},
+	}
+
+	for _, test := range tests {
+		viper.Reset()
+		t.Cleanup(viper.Reset)
+
+		dir := filepath.Join(t.TempDir(), "appsv1")
+		args := append(test.args, "--output-dir", dir)
+
+		root := NewRootCommand()
+		root.SetArgs(args)
+		require.NoError(t, root.Execute(), args)
+		assert.FileExists(t, filepath.Join(dir, "doc.go"))
+		assert.FileExists(t, filepath.Join(dir, "types.go"))
+
+		root = NewRootCommand()
+		root.SetArgs(args)
+		assert.Error(t, root.Execute(), args)
+
+		root = NewRootCommand()
+		root.SetArgs(append(args, "--force"))
+		assert.NoError(t, root.Execute(), args)
+	}
+}
@@ -0,0 +1,157 @@
+package builder
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/kanopy-platform/code-generator/pkg/generators/index"
+	"github.com/kanopy-platform/code-generator/pkg/generators/snippets"
+	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+// applyObjectMetaMembers are the members of ObjectMeta in its apply configuration, the others are set by the server.
+var applyObjectMetaMembers = []string{"Name", "Namespace", "Labels", "Annotations", "OwnerReferences", "Finalizers"}
+
+// generateApplyConfiguration generates the apply configuration of the root type with a field for every member of the parent type that has a setter.
+// Struct members are only allowed with an apply configuration of their own, since all their fields would be serialized otherwise.
+func (b *BuilderPatternGenerator) generateApplyConfiguration(sw *snippetWriter, t *types.Type, apiVersion, kind string) error {
+	parent := getEmbeddedType(t)
+	if parent == nil {
+		return nil
+	}
+
+	var typeMeta, objectMeta *types.Type
+	if p := getParentOfEmbeddedType(t, ObjectMeta); p != nil {
+		parent = p
+		objectMeta = getMemberTypeFromType(p, ObjectMeta)
+		typeMeta = getMemberTypeFromType(p, TypeMeta)
+	}
+
+	fields := []snippets.ApplyField{}
+	for _, m := range index.InlineMembers(parent) {
+		if m.Embedded || reflect.StructTag(m.Tags).Get("json") == "-" || !includeMemberOfRoot(b.members, t, parent, m) {
+			continue
+		}
+		apply := b.applyConfigurationOf(t, m.Type)
+		if apply == "" && !isAtomic(m.Type) {
+			return fmt.Errorf("type %s: member %s has no apply configuration and would be serialized with its zero fields, "+
+				"enable %s on a wrapper of %s in package %s, e.g. with %s", t.Name, m.Name, tags.ApplyConfig, m.Type, t.Name.Package, tags.DeepFlag)
+		}
+		fields = append(fields, snippets.ApplyField{Member: m, JSON: jsonName(m), Apply: apply})
+	}
+
+	sw.Do(snippets.GenerateApplyConfiguration(t, parent, typeMeta, objectMeta, fields))
+	sw.Do(snippets.GenerateApplyConfigurationConstructor(t, objectMeta, apiVersion, kind))
+	if objectMeta != nil {
+		for _, f := range applyObjectMetaFields(objectMeta) {
+			if includeMemberOfRoot(b.members, t, objectMeta, f.Member) {
+				sw.Do(snippets.GenerateApplyObjectMetaSetter(t, objectMeta, f.Member))
+			}
+		}
+	}
+	for _, f := range fields {
+		sw.Do(snippets.GenerateApplySetter(t, f))
+	}
+	sw.Do(snippets.GenerateApplyToUnstructured(t))
+	return nil
+}
+
+// applyObjectMeta returns the upstream ObjectMeta used by the apply configurations of the package, nil if there is none.
+// ObjectMeta is either embedded by the parent of a root type or a member of the parent, e.g. of a pod template.
+func (b *BuilderPatternGenerator) applyObjectMeta() *types.Type {
+	names := []string{}
+	for name, t := range b.pkgToBuild.Types {
+		if b.needsGeneration(t) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	roots := []*types.Type{}
+	for _, name := range names {
+		roots = append(roots, b.pkgToBuild.Types[name])
+	}
+	roots = append(roots, b.packageIndex.SyntheticTypesByPackage[b.pkgToBuild.Path]...)
+
+	for _, t := range roots {
+		parent := getEmbeddedType(t)
+		if parent == nil || t.IsPrimitive() || !b.isOptionEnabled(t, tags.ApplyConfig) {
+			continue
+		}
+		if p := getParentOfEmbeddedType(t, ObjectMeta); p != nil {
+			return getMemberTypeFromType(p, ObjectMeta)
+		}
+		for _, m := range parent.Members {
+			if s := applyElem(m.Type); !m.Embedded && s.Kind == types.Struct && s.Name.Name == ObjectMeta {
+				return s
+			}
+		}
+	}
+	return nil
+}
+
+// applyObjectMetaFields returns the fields of the ObjectMeta apply configuration present in the upstream ObjectMeta.
+func applyObjectMetaFields(objectMeta *types.Type) []snippets.ApplyField {
+	fields := []snippets.ApplyField{}
+	for _, name := range applyObjectMetaMembers {
+		if m := getMemberFromType(objectMeta, name); m.Name != "" {
+			fields = append(fields, snippets.ApplyField{Member: m, JSON: jsonName(m)})
+		}
+	}
+	return fields
+}
+
+// applyConfigurationOf returns the apply configuration of a struct member type, or of the struct held by a pointer or slice member.
+// It is empty for atomic types, which keep their upstream type even if wrapped, and otherwise unless the type is ObjectMeta
+// or its wrapper is generated in the package of the root type with applyconfig enabled.
+func (b *BuilderPatternGenerator) applyConfigurationOf(root *types.Type, t *types.Type) string {
+	if isAtomic(t) {
+		return ""
+	}
+	t = applyElem(t)
+	if t.Name.Name == ObjectMeta {
+		return snippets.ObjectMetaApplyConfiguration
+	}
+
+	wrapper := b.getWrapperType(t)
+	if wrapper == nil || wrapper.Name.Package != root.Name.Package || !b.isOptionEnabled(wrapper, tags.ApplyConfig) {
+		return ""
+	}
+	return snippets.ApplyConfigurationName(wrapper)
+}
+
+// applyElem returns the type held by a slice and then by a pointer.
+func applyElem(t *types.Type) *types.Type {
+	if t.Kind == types.Slice {
+		t = t.Elem
+	}
+	if t.Kind == types.Pointer {
+		t = t.Elem
+	}
+	return t
+}
+
+// isAtomic returns true if a member type is serialized as a whole, i.e. it is not a struct or the struct marshals itself, e.g. a quantity or an IntOrString.
+func isAtomic(t *types.Type) bool {
+	if t.Kind == types.Map {
+		t = t.Elem
+	}
+	t = applyElem(t)
+	if t.Kind != types.Struct || marshalsItself(t) {
+		return true
+	}
+	for _, m := range index.InlineMembers(t) {
+		if !m.Embedded && !namer.IsPrivateGoName(m.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// marshalsItself returns true if the type implements its own JSON or text encoding, which the builders set as a whole.
+func marshalsItself(t *types.Type) bool {
+	return hasMethod(t, "MarshalJSON") || hasMethod(t, "MarshalText")
+}
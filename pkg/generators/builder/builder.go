@@ -1,11 +1,14 @@
 package builder
 
 import (
+	"fmt"
 	"go/token"
 	"io"
 	"strings"
 
 	"github.com/kanopy-platform/code-generator/pkg/generators"
+	"github.com/kanopy-platform/code-generator/pkg/generators/index"
+	"github.com/kanopy-platform/code-generator/pkg/generators/members"
 	"github.com/kanopy-platform/code-generator/pkg/generators/snippets"
 	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
 	"k8s.io/gengo/namer"
@@ -21,13 +24,27 @@ type BuilderPatternGenerator struct {
 	allTypes     bool
 	imports      namer.ImportTracker
 	packageIndex *generators.PackageTypeIndex
+	members      *members.Config
+	templates    snippets.Templates
+	// optionNames maps the setters of the types generated in the options style to their option constructors.
+	optionNames map[*types.Type]map[string]string
+	// recordedOptions collects the setters of each type while the option names are resolved.
+	recordedOptions map[*types.Type][]string
 }
 
 type BuilderPatternGeneratorFactory struct {
 	OutputFileBaseName string
+	// Members configures the generated members, defaults to members.Default().
+	Members *members.Config
+	// Templates replace the built-in templates of snippets.
+	Templates snippets.Templates
 }
 
 func (d *BuilderPatternGeneratorFactory) NewBuilder(pkg *types.Package, packageIndex *generators.PackageTypeIndex) generator.Generator {
+	config := d.Members
+	if config == nil {
+		config = members.Default()
+	}
 
 	return &BuilderPatternGenerator{
 		DefaultGen: generator.DefaultGen{
@@ -37,9 +54,31 @@ func (d *BuilderPatternGeneratorFactory) NewBuilder(pkg *types.Package, packageI
 		allTypes:     isAllTypes(pkg),
 		imports:      newImportTracker(packageIndex),
 		packageIndex: packageIndex,
+		members:      config,
+		templates:    d.Templates,
 	}
 }
 
+// snippetWriter renders snippets with the templates of the generator.
+type snippetWriter struct {
+	*generator.SnippetWriter
+	context   *generator.Context
+	templates snippets.Templates
+}
+
+func (b *BuilderPatternGenerator) newSnippetWriter(w io.Writer, c *generator.Context) *snippetWriter {
+	return &snippetWriter{SnippetWriter: generator.NewSnippetWriter(w, c, "$", "$"), context: c, templates: b.templates}
+}
+
+// Do renders a snippet, replacing its raw template with the user supplied template, if any.
+func (sw *snippetWriter) Do(raw string, args interface{}) *snippetWriter {
+	if a, ok := args.(generator.Args); ok {
+		raw, args = sw.templates.Apply(raw, a)
+	}
+	sw.SnippetWriter.Do(raw, args)
+	return sw
+}
+
 func isAllTypes(pkg *types.Package) bool {
 	return tags.IsPackageTagged(pkg.Comments)
 }
@@ -106,48 +145,259 @@ func pathToLegalGoName(in string) string {
 }
 
 func (b *BuilderPatternGenerator) Init(c *generator.Context, w io.Writer) error {
-	sw := generator.NewSnippetWriter(w, c, "$", "$")
-	sw.Do(snippets.GenerateMergeMapStringString(), nil)
-	sw.Do(snippets.GenerateVariadicBool(), nil)
-	sw.Do(snippets.GenerateBoolPointer(), nil)
+	sw := b.newSnippetWriter(w, c)
+	sw.Do(snippets.GenerateMergeMapStringString())
+	sw.Do(snippets.GenerateVariadicBool())
+	sw.Do(snippets.GenerateBoolPointer())
+	sw.Do(snippets.GenerateContainsString())
+	if b.isRenderEnabled() {
+		sw.Do(snippets.GenerateRenderHelpers())
+	}
+	if objectMeta := b.applyObjectMeta(); objectMeta != nil {
+		sw.Do(snippets.GenerateApplyObjectMeta(objectMeta, applyObjectMetaFields(objectMeta)))
+	}
 	return sw.Error()
 }
 
 func (b *BuilderPatternGenerator) GenerateType(c *generator.Context, t *types.Type, w io.Writer) error {
 	log.Infof("Generating type: %s", t.Name.Name)
 
-	sw := generator.NewSnippetWriter(w, c, "$", "$")
+	sw := b.newSnippetWriter(w, c)
 
 	if t.IsPrimitive() {
-		sw.Do(snippets.GenerateEnumSetter(t, tags.GetEnumOptions(t)))
+		enumOptions, err := b.enumOptions(t)
+		if err != nil {
+			return err
+		}
+		if err := snippets.ValidateEnumOptions(t, enumOptions); err != nil {
+			return err
+		}
+		if err := b.checkEnumConstants(t, enumOptions); err != nil {
+			return err
+		}
+		sw.Do(snippets.GenerateEnumSetter(t, enumOptions))
+		sw.Do(snippets.GenerateEnumHelpers(t, enumOptions))
 		return sw.Error()
 	}
 
+	if tags.IsTypeArgEnabled(t, tags.RenderFlag) && !hasObjectMetaEmbedded(t) {
+		log.Warnf("Type: %s is marked %s but does not embed %s", t.Name, tags.RenderFlag, ObjectMeta)
+	}
+
+	if err := b.generateBuilderForType(sw, t); err != nil {
+		return err
+	}
+	return sw.Error()
+}
+
+// Finalize generates the wrapper types synthesized by deep roots of the package, which are not part of the universe.
+func (b *BuilderPatternGenerator) Finalize(c *generator.Context, w io.Writer) error {
+	sw := b.newSnippetWriter(w, c)
+
+	for _, t := range b.packageIndex.SyntheticTypesByPackage[b.pkgToBuild.Path] {
+		log.Infof("Generating deep type: %s", t.Name.Name)
+		sw.Do(snippets.GenerateWrapperType(t, getEmbeddedType(t)))
+		if err := b.generateBuilderForType(sw, t); err != nil {
+			return err
+		}
+	}
+
+	return sw.Error()
+}
+
+// enumOptions returns the enum values listed on the type, or the constants of the ref type when enum=auto.
+// Constants of the ref type keep their name, with the name of the ref type replaced by the name of the enum type,
+// and are ordered so that the constants prefixed with the type name come first.
+func (b *BuilderPatternGenerator) enumOptions(t *types.Type) ([]snippets.EnumOption, error) {
+	if !tags.IsEnumAuto(t) {
+		return snippets.ParseEnumOptions(t, tags.GetEnumOptions(t)), nil
+	}
+
+	ref := tags.ExtractRef(t)
+	if ref == "" {
+		return nil, fmt.Errorf("type %s: enum=%s requires the ref argument", t.Name, tags.EnumAuto)
+	}
+
+	constants, err := index.EnumConstants(ref)
+	if err != nil {
+		return nil, fmt.Errorf("type %s: %w", t.Name, err)
+	}
+
+	refName := ref[strings.LastIndex(ref, ".")+1:]
+	prefixed, others := []snippets.EnumOption{}, []snippets.EnumOption{}
+	for _, c := range constants {
+		if suffix, found := strings.CutPrefix(c.Name, refName); found {
+			prefixed = append(prefixed, snippets.EnumOption{Name: t.Name.Name + suffix, Value: c.Value})
+		} else {
+			others = append(others, snippets.EnumOption{Name: c.Name, Value: c.Value})
+		}
+	}
+	return append(prefixed, others...), nil
+}
+
+// checkEnumConstants returns an error if a constant of the enum type is declared by the package to build,
+// or is generated for another enum type of the package, either as a constant or as a helper.
+func (b *BuilderPatternGenerator) checkEnumConstants(t *types.Type, enumOptions []snippets.EnumOption) error {
+	generated := map[string]*types.Type{}
+	for _, other := range b.pkgToBuild.Types {
+		if other == t || !other.IsPrimitive() || !b.needsGeneration(other) {
+			continue
+		}
+		options, err := b.enumOptions(other)
+		if err != nil {
+			// reported while generating the other type
+			continue
+		}
+		for _, o := range options {
+			generated[o.Name] = other
+		}
+		for _, name := range snippets.EnumHelperNames(other) {
+			generated[name] = other
+		}
+	}
+
+	for _, o := range enumOptions {
+		if other, ok := generated[o.Name]; ok {
+			return fmt.Errorf("enum value %q of %s generates the constant %s, which is also generated for %s, name it with <Suffix>:<value>", o.Value, t.Name, o.Name, other.Name)
+		}
+		if b.isPackageNameDeclared(o.Name) {
+			return fmt.Errorf("enum value %q of %s generates the constant %s, which is already declared in package %s, name it with <Suffix>:<value>", o.Value, t.Name, o.Name, b.pkgToBuild.Path)
+		}
+	}
+	return nil
+}
+
+// isPackageNameDeclared returns true if the package to build declares the name or a deep root of the package synthesizes it.
+func (b *BuilderPatternGenerator) isPackageNameDeclared(name string) bool {
+	if b.isTypeNameTaken(name) {
+		return true
+	}
+	_, function := b.pkgToBuild.Functions[name]
+	_, variable := b.pkgToBuild.Variables[name]
+	_, constant := b.pkgToBuild.Constants[name]
+	return function || variable || constant
+}
+
+func (b *BuilderPatternGenerator) generateBuilderForType(sw *snippetWriter, t *types.Type) error {
+	options, err := b.isOptionsStyle(t)
+	if err != nil {
+		return err
+	}
+
+	if !options && b.isOptionEnabled(t, tags.ImmutableFlag) && !canDeepCopy(t) {
+		return fmt.Errorf("type %s: %s requires the parent type to implement DeepCopyInto", t.Name, tags.ImmutableFlag)
+	}
+
+	apiVersion, kind, err := typeMeta(t)
+	if err != nil {
+		return err
+	}
+
+	if options {
+		if name := t.Name.Name + "Option"; b.isTypeNameTaken(name) {
+			return fmt.Errorf("type %s: functional option type %s collides with a type of package %s, wrap that type under another name or use %s=%s", t.Name, name, b.pkgToBuild.Path, tags.StyleFlag, tags.StyleBuilder)
+		}
+		if err := b.resolveOptionNames(sw.context); err != nil {
+			return err
+		}
+		sw.Do(snippets.GenerateOptionType(t))
+	}
+
+	var objectMetaType *types.Type
 	if hasObjectMetaEmbedded(t) {
+		namespaced, err := isNamespaced(t)
+		if err != nil {
+			return err
+		}
+
 		parentTypeOfObjectMeta := getParentOfEmbeddedType(t, ObjectMeta)
-		objectMetaType := getMemberTypeFromType(parentTypeOfObjectMeta, ObjectMeta)
+		objectMetaType = getMemberTypeFromType(parentTypeOfObjectMeta, ObjectMeta)
 		b.imports.AddType(parentTypeOfObjectMeta)
 		b.imports.AddType(objectMetaType)
-		sw.Do(snippets.GenerateConstructorForObjectMeta(t))
-		sw.Do(snippets.GenerateDeepCopy(t))
+		sw.Do(snippets.GenerateConstructorForObjectMeta(t, apiVersion, kind, options))
+		if namespaced {
+			sw.Do(snippets.GenerateConstructorInNamespace(t, options))
+		}
+		sw.Do(snippets.GenerateDeepCopy(t, getEmbeddedType(t)))
+		if getParentOfEmbeddedType(t, TypeMeta) != nil {
+			sw.Do(snippets.GenerateDeepCopyObject(t))
+		}
+		sw.Do(snippets.GenerateToUnstructured(t, parentTypeOfObjectMeta))
+		sw.Do(snippets.GenerateFromUnstructured(t, parentTypeOfObjectMeta))
+		if b.isRendered(t) {
+			sw.Do(snippets.GenerateRender(t))
+		}
 		b.generateSettersForType(sw, t, objectMetaType)
+		b.generateObjectMetaHelpers(sw, t, objectMetaType)
+		if b.isOptionEnabled(t, tags.GettersFlag) {
+			b.generateGettersForType(sw, t, objectMetaType)
+		}
 	} else {
-		sw.Do(snippets.GenerateEmptyConstructor(t, true))
+		required, err := b.requiredMembers(t)
+		if err != nil {
+			return err
+		}
+		if len(required) > 0 {
+			sw.Do(snippets.GenerateConstructorWithRequired(t, getEmbeddedType(t), required, options))
+		} else {
+			sw.Do(snippets.GenerateEmptyConstructor(t, true, options))
+		}
+		if b.isCopyOnWrite(t) {
+			sw.Do(snippets.GenerateDeepCopy(t, getEmbeddedType(t)))
+		}
+	}
+
+	if parent := getEmbeddedType(t); parent != nil {
+		if canDeepCopy(t) {
+			sw.Do(snippets.GenerateConstructorFrom(t, parent))
+		}
+		sw.Do(snippets.GenerateWrapConstructor(t, parent))
 	}
 
 	for _, member := range t.Members {
 		log.Debugf("generateSettersForType %v - Type : %v", member.Name, member.Type)
 		b.generateSettersForType(sw, t, member.Type)
+		if match, ok := findLabelSelectorMatch(member.Type); ok {
+			sw.Do(b.newSetter(t, member.Type).GenerateWithLabelSelectorMatch(match))
+		}
+		if b.isOptionEnabled(t, tags.GettersFlag) {
+			b.generateGettersForType(sw, t, member.Type)
+		}
 	}
 
-	return sw.Error()
+	if b.isOptionEnabled(t, tags.ApplyConfig) {
+		if err := b.generateApplyConfiguration(sw, t, apiVersion, kind); err != nil {
+			return err
+		}
+	}
+
+	if parent := getEmbeddedType(t); parent != nil {
+		validator, err := b.newValidatorForType(t, objectMetaType)
+		if err != nil {
+			return err
+		}
+		sw.Do(validator.GenerateValidate())
+		sw.Do(snippets.GenerateBuild(t, parent, canDeepCopy(t)))
+	}
+
+	return nil
+}
+
+func (b *BuilderPatternGenerator) newSetter(root *types.Type, parent *types.Type) *snippets.Setter {
+	setterOpts := []func(*snippets.Setter){}
+	if options, _ := b.isOptionsStyle(root); options {
+		setterOpts = append(setterOpts, snippets.WithOptionsStyle(b.optionName(root)))
+	} else if b.isCopyOnWrite(root) {
+		setterOpts = append(setterOpts, snippets.WithCopyOnWrite())
+	}
+	return snippets.NewSetter(root, parent, true, setterOpts...)
 }
 
-func (b *BuilderPatternGenerator) generateSettersForType(sw *generator.SnippetWriter, root *types.Type, parent *types.Type) {
-	setter := snippets.NewSetter(root, parent, true)
+func (b *BuilderPatternGenerator) generateSettersForType(sw *snippetWriter, root *types.Type, parent *types.Type) {
+	setter := b.newSetter(root, parent)
 
-	for _, m := range parent.Members {
-		if m.Embedded || !includeMember(parent, m) {
+	for _, m := range index.InlineMembers(parent) {
+		if m.Embedded || !includeMemberOfRoot(b.members, root, parent, m) {
 			continue
 		}
 
@@ -160,21 +410,37 @@ func (b *BuilderPatternGenerator) generateSettersForType(sw *generator.SnippetWr
 			switch {
 			case keyType == types.String && elemType == types.String:
 				sw.Do(setter.GenerateSetterForMapStringString(m))
+				// the keys of labels and annotations are deleted by RemoveLabel and RemoveAnnotation
+				if !isObjectMetaMapHelper(parent, m) {
+					sw.Do(setter.GenerateSetterForMapDelete(m))
+				}
+			case isStructOrPointerToStruct(elemType) && b.isTypeEnabled(elemType):
+				log.Debugf("\t %v is enabled -> GenerateSetterForEmbeddedMap", elemType)
+				sw.Do(setter.GenerateSetterForEmbeddedMap(m, b.getWrapperType(elemType)))
+				sw.Do(setter.GenerateSetterForMapDelete(m))
 			default:
 				sw.Do(setter.GenerateSetterForMap(m))
+				sw.Do(setter.GenerateSetterForMapPut(m))
+				sw.Do(setter.GenerateSetterForMapDelete(m))
 			}
 		case m.Type.Kind == types.Slice:
 			sliceType := m.Type.Elem
+			var appendSetter func(s *snippets.Setter) (string, generator.Args)
 			switch sliceType.Kind {
 			case types.Struct, types.Pointer:
 				log.Debugf("generateSettersForType - Slice -> Struct : %v - Type : %v", m.Name, m.Type)
 				if b.isTypeEnabled(m.Type) {
+					wrap := b.getWrapperType(sliceType)
 					if sliceType.Kind == types.Pointer {
 						log.Debugf("\t %v is enabled -> GenerateSetterForEmbeddedSlicePointer", m.Type)
-						sw.Do(setter.GenerateSetterForEmbeddedSlicePointer(m, b.getWrapperType(sliceType)))
+						appendSetter = func(s *snippets.Setter) (string, generator.Args) {
+							return s.GenerateSetterForEmbeddedSlicePointer(m, wrap)
+						}
 					} else {
 						log.Debugf("\t %v is enabled -> GenerateSetterForEmbeddedSlice", m.Type)
-						sw.Do(setter.GenerateSetterForEmbeddedSlice(m, b.getWrapperType(sliceType)))
+						appendSetter = func(s *snippets.Setter) (string, generator.Args) {
+							return s.GenerateSetterForEmbeddedSlice(m, wrap)
+						}
 					}
 				}
 			default:
@@ -183,17 +449,32 @@ func (b *BuilderPatternGenerator) generateSettersForType(sw *generator.SnippetWr
 
 					if sliceType.Kind == types.Alias {
 						wrap := b.getWrapperType(m.Type)
-						sw.Do(setter.GenerateSetterForEmbeddedSliceEnum(m, wrap))
+						appendSetter = func(s *snippets.Setter) (string, generator.Args) {
+							return s.GenerateSetterForEmbeddedSliceEnum(m, wrap)
+						}
 					} else {
-						sw.Do(setter.GenerateSetterForMemberSlice(m))
+						appendSetter = func(s *snippets.Setter) (string, generator.Args) {
+							return s.GenerateSetterForMemberSlice(m)
+						}
 					}
 				}
 			}
+
+			if appendSetter != nil {
+				sw.Do(appendSetter(setter))
+				if sliceType != types.Byte {
+					sw.Do(appendSetter(setter.Replacing()))
+					sw.Do(setter.GenerateSetterForSliceClear(m))
+					sw.Do(setter.GenerateSetterForSliceRemove(m))
+				}
+			}
 		case m.Type.Kind == types.Struct:
 			log.Debugf("generateSettersForType - Struct : %v", m.Type)
 			if b.isTypeEnabled(m.Type) {
 				log.Debugf("\t %v is enabled", m.Type)
 				sw.Do(setter.GenerateSetterForEmbeddedStruct(m, b.getWrapperType(m.Type)))
+			} else if marshalsItself(m.Type) {
+				sw.Do(setter.GenerateSetterForType(m))
 			}
 		case m.Type.Kind == types.Pointer:
 			pointerType := m.Type.Elem
@@ -209,6 +490,8 @@ func (b *BuilderPatternGenerator) generateSettersForType(sw *generator.SnippetWr
 				if b.isTypeEnabled(pointerType) {
 					log.Debugf("\t %v is enabled", pointerType)
 					sw.Do(setter.GenerateSetterForEmbeddedPointer(m, b.getWrapperType(pointerType)))
+				} else if marshalsItself(pointerType) {
+					sw.Do(setter.GenerateSetterForType(m))
 				}
 			case types.Alias:
 				log.Debugf("generateSettersForType - Alias : %v", m.Type)
@@ -237,6 +520,188 @@ func (b *BuilderPatternGenerator) generateSettersForType(sw *generator.SnippetWr
 	}
 }
 
+// objectMetaMapHelpers names the single key helpers of the ObjectMeta maps, e.g. WithLabel and RemoveLabel.
+var objectMetaMapHelpers = map[string]string{
+	"Labels":      "Label",
+	"Annotations": "Annotation",
+}
+
+// isObjectMetaMapHelper returns true if the member is an ObjectMeta map with single key helpers.
+func isObjectMetaMapHelper(parent *types.Type, m types.Member) bool {
+	return parent.Name.Name == ObjectMeta && objectMetaMapHelpers[m.Name] != "" && isMapStringString(m.Type)
+}
+
+// generateObjectMetaHelpers generates the label and annotation helpers of included members and
+// the finalizer and owner reference helpers independent of the member rules.
+func (b *BuilderPatternGenerator) generateObjectMetaHelpers(sw *snippetWriter, root *types.Type, objectMeta *types.Type) {
+	setter := b.newSetter(root, objectMeta)
+
+	for _, m := range objectMeta.Members {
+		switch {
+		case isObjectMetaMapHelper(objectMeta, m) && includeMemberOfRoot(b.members, root, objectMeta, m):
+			sw.Do(setter.GenerateSetterForMapKey(m, objectMetaMapHelpers[m.Name]))
+			sw.Do(setter.GenerateSetterForMapKeyRemove(m, objectMetaMapHelpers[m.Name]))
+		case m.Name == "Finalizers" && m.Type.Kind == types.Slice && m.Type.Elem == types.String:
+			sw.Do(setter.GenerateAddFinalizer(m))
+			sw.Do(setter.GenerateRemoveFinalizer(m))
+			sw.Do(setter.GenerateHasFinalizer(m))
+		case m.Name == "OwnerReferences" && m.Type.Kind == types.Slice && m.Type.Elem.Kind == types.Struct:
+			sw.Do(setter.GenerateWithOwner(m))
+		}
+	}
+}
+
+func (b *BuilderPatternGenerator) generateGettersForType(sw *snippetWriter, root *types.Type, parent *types.Type) {
+	getter := snippets.NewGetter(root, parent)
+
+	for _, m := range index.InlineMembers(parent) {
+		if m.Embedded || !includeMemberOfRoot(b.members, root, parent, m) || snippets.HasGetter(parent, m) {
+			continue
+		}
+
+		log.Debugf("generateGettersForType %v - Type : %v -- Kind: %s", m.Name, m.Type, m.Type.Kind)
+
+		switch {
+		case m.Type.Kind == types.Map:
+			sw.Do(getter.GenerateGetterForType(m))
+		case m.Type.Kind == types.Slice:
+			sliceType := m.Type.Elem
+			switch sliceType.Kind {
+			case types.Struct, types.Pointer:
+				if b.isTypeEnabled(m.Type) {
+					if sliceType.Kind == types.Pointer {
+						sw.Do(getter.GenerateGetterForEmbeddedSlicePointer(m, b.getWrapperType(sliceType)))
+					} else {
+						sw.Do(getter.GenerateGetterForEmbeddedSlice(m, b.getWrapperType(sliceType)))
+					}
+				}
+			default:
+				if b.isTypeEnabled(m.Type) || sliceType.Kind == types.Builtin {
+					if sliceType.Kind == types.Alias {
+						sw.Do(getter.GenerateGetterForEmbeddedSliceEnum(m, b.getWrapperType(m.Type)))
+					} else {
+						sw.Do(getter.GenerateGetterForType(m))
+					}
+				}
+			}
+		case m.Type.Kind == types.Struct:
+			if b.isTypeEnabled(m.Type) {
+				sw.Do(getter.GenerateGetterForEmbeddedStruct(m, b.getWrapperType(m.Type)))
+			} else if marshalsItself(m.Type) {
+				sw.Do(getter.GenerateGetterForType(m))
+			}
+		case m.Type.Kind == types.Pointer:
+			pointerType := m.Type.Elem
+			switch pointerType.Kind {
+			case types.Builtin:
+				sw.Do(getter.GenerateGetterForPointerToBuiltinType(m))
+			case types.Struct:
+				if b.isTypeEnabled(pointerType) {
+					sw.Do(getter.GenerateGetterForEmbeddedPointer(m, b.getWrapperType(pointerType)))
+				} else if marshalsItself(pointerType) {
+					sw.Do(getter.GenerateGetterForType(m))
+				}
+			case types.Alias:
+				if b.isTypeEnabled(m.Type) {
+					sw.Do(getter.GenerateGetterForAliasPointerPrimitive(m, b.getWrapperType(m.Type)))
+				}
+			default:
+				sw.Do(getter.GenerateGetterForType(m))
+			}
+		case m.Type == types.Bool:
+			sw.Do(getter.GenerateGetterForType(m))
+		case m.Type.Kind == types.Alias:
+			if m.Type.Underlying.Kind == types.Builtin && b.isTypeEnabled(m.Type) {
+				sw.Do(getter.GenerateGetterForTypeEnum(m, b.getWrapperType(m.Type)))
+			}
+		default:
+			if b.isTypeEnabled(m.Type) {
+				sw.Do(getter.GenerateGetterForType(m))
+			}
+		}
+	}
+}
+
+func (b *BuilderPatternGenerator) newValidatorForType(root *types.Type, objectMetaType *types.Type) (*snippets.Validator, error) {
+	validator := snippets.NewValidator(root)
+
+	if objectMetaType != nil {
+		if err := b.addValidationsForType(validator, objectMetaType, "metadata"); err != nil {
+			return nil, err
+		}
+		for _, m := range objectMetaType.Members {
+			if m.Name == "OwnerReferences" && m.Type.Kind == types.Slice && m.Type.Elem.Kind == types.Struct {
+				validator.AddOwnerReferences(objectMetaType, m, "metadata", jsonName(m))
+			}
+		}
+	}
+
+	for _, member := range root.Members {
+		if err := b.addValidationsForType(validator, member.Type); err != nil {
+			return nil, err
+		}
+	}
+
+	return validator, nil
+}
+
+func (b *BuilderPatternGenerator) addValidationsForType(validator *snippets.Validator, parent *types.Type, path ...string) error {
+	return b.addNestedValidations(validator, parent, parent, nil, path)
+}
+
+// addNestedValidations adds the validations of the members of t, reached from parent through the struct members of via,
+// and descends into struct and pointer to struct members and into the struct elements of slice and string keyed map members.
+// Parent and the types of via are not walked again.
+func (b *BuilderPatternGenerator) addNestedValidations(validator *snippets.Validator, parent *types.Type, t *types.Type, via []types.Member, path []string) error {
+	for _, m := range t.Members {
+		if m.Embedded || !includeMemberOfRoot(b.members, validator.Root, t, m) {
+			continue
+		}
+
+		memberPath := append(append([]string{}, path...), jsonName(m))
+		if validation := tags.ExtractValidation(m); !validation.IsEmpty() {
+			log.Debugf("addNestedValidations %v - %+v", m.Name, validation)
+			if err := validator.AddMember(parent, via, m, validation, memberPath...); err != nil {
+				return fmt.Errorf("type %s: %w", validator.Root.Name, err)
+			}
+		}
+
+		nested := nestedStruct(m.Type)
+		if nested == nil || nested == parent || isWalked(via, nested) {
+			continue
+		}
+		if err := b.addNestedValidations(validator, parent, nested, append(append([]types.Member{}, via...), m), memberPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nestedStruct returns the struct type validated through a member of type t, i.e. t itself, the type it points to
+// or the element of a slice or string keyed map, or nil if there is none.
+func nestedStruct(t *types.Type) *types.Type {
+	if t.Kind == types.Slice || (t.Kind == types.Map && (t.Key == types.String || t.Key.Underlying == types.String)) {
+		t = t.Elem
+	}
+	if t.Kind == types.Pointer {
+		t = t.Elem
+	}
+	if t.Kind != types.Struct {
+		return nil
+	}
+	return t
+}
+
+// isWalked returns true if t is the struct type validated through one of the members of via.
+func isWalked(via []types.Member, t *types.Type) bool {
+	for _, m := range via {
+		if nestedStruct(m.Type) == t {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *BuilderPatternGenerator) needsGeneration(t *types.Type) bool {
 	if b.doesTypeOptout(t) || (!b.doesTypeNeedGeneration(t) && !b.allTypes) {
 		return false
@@ -245,6 +710,89 @@ func (b *BuilderPatternGenerator) needsGeneration(t *types.Type) bool {
 	return true
 }
 
+// isOptionEnabled returns true if the argument is enabled on the type, or else for the whole package.
+// A value on the type, e.g. immutable=false, takes precedence over the package.
+func (b *BuilderPatternGenerator) isOptionEnabled(t *types.Type, arg string) bool {
+	if tags.ExtractTypeArg(t, arg) != "" {
+		return tags.IsTypeArgEnabled(t, arg)
+	}
+	return tags.IsArgEnabled(b.pkgToBuild.Comments, arg)
+}
+
+// isTypeNameTaken returns true if the package to build declares the type name, or a deep root of the package synthesizes it.
+func (b *BuilderPatternGenerator) isTypeNameTaken(name string) bool {
+	if _, ok := b.pkgToBuild.Types[name]; ok {
+		return true
+	}
+	for _, t := range b.packageIndex.SyntheticTypesByPackage[b.pkgToBuild.Path] {
+		if t.Name.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isOptionsStyle returns true if the type, or else its package, selects the functional options style.
+func (b *BuilderPatternGenerator) isOptionsStyle(t *types.Type) (bool, error) {
+	style := tags.ExtractTypeStyle(t)
+	if style == "" {
+		style = tags.ExtractStyle(b.pkgToBuild.Comments)
+	}
+
+	switch style {
+	case "", tags.StyleBuilder:
+		return false, nil
+	case tags.StyleOptions:
+		return true, nil
+	default:
+		return false, fmt.Errorf("type %s: %s=%s must be %s or %s", t.Name, tags.StyleFlag, style, tags.StyleBuilder, tags.StyleOptions)
+	}
+}
+
+// isRenderEnabled returns true if the package, or a type generated in the package, renders manifests using the package level helpers.
+// Wrappers synthesized by deep roots are generated in the package as well.
+func (b *BuilderPatternGenerator) isRenderEnabled() bool {
+	if tags.IsArgEnabled(b.pkgToBuild.Comments, tags.RenderFlag) {
+		return true
+	}
+
+	for _, t := range b.pkgToBuild.Types {
+		if b.needsGeneration(t) && b.isRendered(t) {
+			return true
+		}
+	}
+	for _, t := range b.packageIndex.SyntheticTypesByPackage[b.pkgToBuild.Path] {
+		if b.isRendered(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRendered returns true if ToJSON and ToYAML are generated for the type, which requires ObjectMeta.
+func (b *BuilderPatternGenerator) isRendered(t *types.Type) bool {
+	return hasObjectMetaEmbedded(t) && b.isOptionEnabled(t, tags.RenderFlag)
+}
+
+// isCopyOnWrite returns true if setters of the type must not mutate the receiver.
+// Copy on write requires a DeepCopy method, which is only generated when the parent type supports it.
+func (b *BuilderPatternGenerator) isCopyOnWrite(t *types.Type) bool {
+	return b.isOptionEnabled(t, tags.ImmutableFlag) && canDeepCopy(t)
+}
+
+// canDeepCopy returns true if the parent type of the wrapper implements DeepCopyInto, which is assumed for ObjectMeta types.
+func canDeepCopy(t *types.Type) bool {
+	if hasObjectMetaEmbedded(t) {
+		return true
+	}
+
+	if parent := getEmbeddedType(t); parent != nil && index.HasMethod(parent, "DeepCopyInto") {
+		return true
+	}
+
+	return false
+}
+
 func (b *BuilderPatternGenerator) doesTypeOptout(t *types.Type) bool {
 	return tags.IsTypeOptedOut(t)
 }
@@ -276,6 +824,68 @@ func (b *BuilderPatternGenerator) getWrapperType(t *types.Type) *types.Type {
 	return b.packageIndex.TypesByTypePath[typeName]
 }
 
+func isMapStringString(t *types.Type) bool {
+	return t != nil && t.Kind == types.Map && t.Key == types.String && t.Elem == types.String
+}
+
+// findLabelSelectorMatch finds a Selector and a Template member in the parent type or in one of its struct members, e.g. DeploymentSpec.
+func findLabelSelectorMatch(parent *types.Type) (snippets.LabelSelectorMatch, bool) {
+	if match, ok := labelSelectorMatchOf(parent); ok {
+		return match, true
+	}
+
+	for _, m := range parent.Members {
+		if m.Embedded || m.Type.Kind != types.Struct {
+			continue
+		}
+		if match, ok := labelSelectorMatchOf(m.Type); ok {
+			match.Path = []types.Member{m}
+			return match, true
+		}
+	}
+
+	return snippets.LabelSelectorMatch{}, false
+}
+
+func labelSelectorMatchOf(t *types.Type) (snippets.LabelSelectorMatch, bool) {
+	selector := getMemberFromType(t, "Selector")
+	template := getMemberFromType(t, "Template")
+	if selector.Type == nil || template.Type == nil {
+		return snippets.LabelSelectorMatch{}, false
+	}
+
+	selectorType := underlyingStruct(selector.Type)
+	if !isMapStringString(selector.Type) && (selectorType == nil || !isMapStringString(getMemberTypeFromType(selectorType, "MatchLabels"))) {
+		return snippets.LabelSelectorMatch{}, false
+	}
+
+	templateType := underlyingStruct(template.Type)
+	if templateType == nil {
+		return snippets.LabelSelectorMatch{}, false
+	}
+	objectMeta := getMemberTypeFromType(templateType, ObjectMeta)
+	if objectMeta == nil || !isMapStringString(getMemberTypeFromType(objectMeta, "Labels")) {
+		return snippets.LabelSelectorMatch{}, false
+	}
+
+	return snippets.LabelSelectorMatch{Selector: selector, Template: template}, true
+}
+
+// underlyingStruct returns the struct or the struct pointed to, nil for other types.
+func underlyingStruct(t *types.Type) *types.Type {
+	if t.Kind == types.Pointer {
+		t = t.Elem
+	}
+	if t.Kind != types.Struct {
+		return nil
+	}
+	return t
+}
+
+func isStructOrPointerToStruct(t *types.Type) bool {
+	return t.Kind == types.Struct || (t.Kind == types.Pointer && t.Elem.Kind == types.Struct)
+}
+
 func hasObjectMetaEmbedded(t *types.Type) bool {
 	if p := getParentOfEmbeddedType(t, ObjectMeta); p != nil {
 		return true
@@ -294,6 +904,20 @@ func getParentOfEmbeddedType(t *types.Type, name string) *types.Type {
 	return nil
 }
 
+func getEmbeddedType(t *types.Type) *types.Type {
+	for _, m := range t.Members {
+		if m.Embedded && m.Type.Kind == types.Struct {
+			return m.Type
+		}
+	}
+	return nil
+}
+
+func hasMethod(t *types.Type, name string) bool {
+	_, ok := t.Methods[name]
+	return ok
+}
+
 func getMemberFromType(t *types.Type, name string) types.Member {
 	for _, mm := range t.Members {
 		if mm.Name == name {
@@ -2,14 +2,26 @@ package builder
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
 
 	"github.com/kanopy-platform/code-generator/pkg/generators"
 	"github.com/kanopy-platform/code-generator/pkg/generators/index"
+	"github.com/kanopy-platform/code-generator/pkg/generators/members"
+	"github.com/kanopy-platform/code-generator/pkg/generators/snippets"
+	"github.com/kanopy-platform/code-generator/pkg/scaffold"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/imports"
+	// testdata/i wraps upstream types, whose packages must be part of the module
+	_ "k8s.io/api/apps/v1"
+	_ "k8s.io/api/core/v1"
 	"k8s.io/gengo/args"
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/types"
@@ -30,13 +42,25 @@ func newTestGeneratorType(t *testing.T, dir string, selector string) (*types.Pac
 	pkg := findTypes[testDir]
 	assert.NotNil(t, pkg)
 
-	defaultIndex.TypesByTypePath = index.BuildPackageIndex(defaultIndex.TypesByTypePath, pkg)
+	indexPackage(defaultIndex, pkg)
 
 	n := pkg.Types[selector]
 	assert.NotNil(t, n)
 	return pkg, n
 }
 
+// indexPackage adds the wrappers of pkg, including the wrappers synthesized by its deep roots, to packageIndex.
+func indexPackage(packageIndex *generators.PackageTypeIndex, pkg *types.Package) {
+	packageIndex.TypesByTypePath = index.BuildPackageIndex(packageIndex.TypesByTypePath, pkg)
+	if _, ok := packageIndex.SyntheticTypesByPackage[pkg.Path]; !ok {
+		var synthetic []*types.Type
+		packageIndex.TypesByTypePath, synthetic = index.BuildDeepPackageIndex(packageIndex.TypesByTypePath, pkg)
+		if len(synthetic) > 0 {
+			packageIndex.SyntheticTypesByPackage[pkg.Path] = synthetic
+		}
+	}
+}
+
 func newGeneratorContext(g generator.Generator) *generator.Context {
 	c := &generator.Context{}
 	c.Namers = g.Namers(c)
@@ -108,9 +132,31 @@ func TestBuilderPattern_ObjectMetaGeneratesSnippets(t *testing.T) {
 	// setters
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithName(in string) *CDeployment")
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithSpec(in *MockSpec) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithPrimitive(in int) *CDeployment")
+	assert.NotContains(t, buf.String(), "AppendFinalizers")
+	// object meta helpers
+	assert.Contains(t, buf.String(), "func (o *CDeployment) AddFinalizer(finalizer string) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) RemoveFinalizer(finalizer string) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) HasFinalizer(finalizer string) bool")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithOwner(owner cmeta.Object, controller bool) *CDeployment")
+	assert.Contains(t, buf.String(), "ref := cmeta.OwnerReference{Name: owner.GetName(), UID: owner.GetUID()}")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithLabel(key string, value string) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) RemoveLabel(keys ...string) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithAnnotation(key string, value string) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) RemoveAnnotation(keys ...string) *CDeployment")
+	// label selector
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithLabelSelectorMatch(key, value string) *CDeployment")
+	assert.Contains(t, buf.String(), "o.MockDeployment.Spec.Selector = &cmeta.LabelSelector{}")
+	assert.Contains(t, buf.String(), "o.MockDeployment.Spec.Selector.MatchLabels[key] = value")
+	assert.Contains(t, buf.String(), "o.MockDeployment.Spec.Template.ObjectMeta.Labels[key] = value")
 	// deepcopy
 	assert.Contains(t, buf.String(), "func (in *CDeployment) DeepCopy() *CDeployment")
 	assert.Contains(t, buf.String(), "func (in *CDeployment) DeepCopyInto(out *CDeployment)")
+	// validate
+	assert.Contains(t, buf.String(), "func (o *CDeployment) Validate() error")
+	// build
+	assert.Contains(t, buf.String(), "func (o *CDeployment) Build() (*cd.MockDeployment, error)")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) MustBuild() *cd.MockDeployment")
 }
 
 func TestBuilderPattern_NonObjectMetaGeneratesSnippets(t *testing.T) {
@@ -127,11 +173,46 @@ func TestBuilderPattern_NonObjectMetaGeneratesSnippets(t *testing.T) {
 	// setters
 	assert.Contains(t, buf.String(), "func (o *DPolicyRule) AppendVerbs(in ...string) *DPolicyRule")
 	assert.Contains(t, buf.String(), "func (o *DPolicyRule) AppendListOfInts(in ...int) *DPolicyRule")
+	assert.Contains(t, buf.String(), "func (o *DPolicyRule) SetVerbs(in ...string) *DPolicyRule")
+	assert.Contains(t, buf.String(), "func (o *DPolicyRule) ClearVerbs() *DPolicyRule")
+	assert.Contains(t, buf.String(), "func (o *DPolicyRule) RemoveVerbs(predicate func(string) bool) *DPolicyRule")
+	// validate
+	assert.Contains(t, buf.String(), "func (o *DPolicyRule) Validate() error")
+	assert.Contains(t, buf.String(), `validationfield.Required(validationfield.NewPath("verbs"), "")`)
+	// build
+	assert.Contains(t, buf.String(), "func (o *DPolicyRule) Build() (*de.MockPolicyRule, error)")
+	assert.Contains(t, buf.String(), "func (o *DPolicyRule) MustBuild() *de.MockPolicyRule")
 	// no deepcopy
 	assert.NotContains(t, buf.String(), "DeepCopy")
 	assert.NotContains(t, buf.String(), "DeepCopyInto")
 }
 
+func TestBuilderPattern_ValidateNestedMembers(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "d", "DRoleBinding")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+
+	assert.Contains(t, buf.String(), `if o.MockRoleBinding.Subject.Name == "" {`)
+	assert.Contains(t, buf.String(), `validationfield.Required(validationfield.NewPath("subject", "name"), "")`)
+	assert.Contains(t, buf.String(), `if o.MockRoleBinding.RoleRef != nil && !containsString([]string{"Role", "ClusterRole"}, string(o.MockRoleBinding.RoleRef.Kind)) {`)
+	assert.Contains(t, buf.String(), `validationfield.NewPath("roleRef", "kind")`)
+	assert.NotContains(t, buf.String(), "RoleRef.Parent")
+	assert.Contains(t, buf.String(), `	for i0 := range o.MockRoleBinding.Items {
+		if len(o.MockRoleBinding.Items[i0].Key) < 3 {
+			allErrs = append(allErrs, validationfield.Invalid(validationfield.NewPath("items").Index(i0).Child("key"), o.MockRoleBinding.Items[i0].Key, "must be at least 3 characters long"))
+		}
+	}
+`)
+	assert.Contains(t, buf.String(), `	for k0 := range o.MockRoleBinding.ItemsMap {
+		if o.MockRoleBinding.ItemsMap[k0] != nil && len(o.MockRoleBinding.ItemsMap[k0].Key) < 3 {
+			allErrs = append(allErrs, validationfield.Invalid(validationfield.NewPath("itemsMap").Key(k0).Child("key"), o.MockRoleBinding.ItemsMap[k0].Key, "must be at least 3 characters long"))
+		}
+	}
+`)
+}
+
 func TestBuilderAliasPrimitiveType(t *testing.T) {
 	b := &BuilderPatternGeneratorFactory{}
 	pkg, typeToGenerate := newTestGeneratorType(t, "d", "DPolicyRule")
@@ -144,6 +225,85 @@ func TestBuilderAliasPrimitiveType(t *testing.T) {
 	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
 	t.Log(buf.String())
 	assert.Contains(t, buf.String(), "func (o *DPolicyRule) WithAliasType(in AliasType) *DPolicyRule")
+
+	buf.Reset()
+	assert.NoError(t, g.GenerateType(c, aliasToGenerate, buf))
+	assert.NotContains(t, buf.String(), "AliasTypeValues", "no enum helpers without enum values")
+}
+
+func TestBuilderEnumAuto(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, enumToGenerate := newTestGeneratorType(t, "d", "Protocol")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.True(t, g.Filter(c, enumToGenerate))
+	assert.NoError(t, g.GenerateType(c, enumToGenerate, buf))
+
+	// constants are named after the upstream constants
+	assert.Contains(t, buf.String(), `const ProtocolCustom Protocol = "Custom:example.com/proto=1"
+const ProtocolSCTP Protocol = "SCTP"
+const ProtocolTCP Protocol = "TCP"
+const ProtocolUDP Protocol = "UDP"
+const DefaultProtocol Protocol = "TCP"
+`)
+	assert.Contains(t, buf.String(), "return []Protocol{ProtocolCustom, ProtocolSCTP, ProtocolTCP, ProtocolUDP}")
+	assert.Contains(t, buf.String(), "func ParseProtocol(in string) (Protocol, error)")
+
+	withoutRef := &types.Type{
+		Name:         types.Name{Package: pkg.Path, Name: "WithoutRef"},
+		Kind:         types.Alias,
+		Underlying:   types.String,
+		CommentLines: []string{"+kanopy:builder=true,enum=auto"},
+	}
+	assert.ErrorContains(t, g.GenerateType(c, withoutRef, &bytes.Buffer{}), "requires the ref argument")
+}
+
+func TestBuilderEnumTyped(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, enumToGenerate := newTestGeneratorType(t, "d", "Phase")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.GenerateType(c, enumToGenerate, buf))
+
+	assert.Contains(t, buf.String(), `const PhasePending Phase = 0
+const PhaseRunning Phase = 1
+const PhaseFailed Phase = -1
+`)
+	assert.Contains(t, buf.String(), "return fmt.Sprint(int32(in))")
+
+	invalid := &types.Type{
+		Name:         types.Name{Package: pkg.Path, Name: "InvalidPhase"},
+		Kind:         types.Alias,
+		Underlying:   types.Int32,
+		CommentLines: []string{"+kanopy:builder=true,enum=Pending:zero"},
+	}
+	assert.ErrorContains(t, g.GenerateType(c, invalid, &bytes.Buffer{}), "is not a valid int32")
+
+	collision := &types.Type{
+		Name:         types.Name{Package: pkg.Path, Name: "SecretType"},
+		Kind:         types.Alias,
+		Underlying:   types.String,
+		CommentLines: []string{"+kanopy:builder=true,enum=kubernetes.io/tls;example.com/tls"},
+	}
+	assert.ErrorContains(t, g.GenerateType(c, collision, &bytes.Buffer{}), "both generate the constant SecretTypeTls")
+
+	// constant names are unique in the whole package
+	helper := &types.Type{
+		Name:         types.Name{Package: pkg.Path, Name: "Parse"},
+		Kind:         types.Alias,
+		Underlying:   types.String,
+		CommentLines: []string{"+kanopy:builder=true,enum=Phase:phase"},
+	}
+	assert.ErrorContains(t, g.GenerateType(c, helper, &bytes.Buffer{}), "generates the constant ParsePhase, which is also generated for")
+	declared := &types.Type{
+		Name:         types.Name{Package: pkg.Path, Name: "D"},
+		Kind:         types.Alias,
+		Underlying:   types.String,
+		CommentLines: []string{"+kanopy:builder=true,enum=PolicyRule:rule"},
+	}
+	assert.ErrorContains(t, g.GenerateType(c, declared, &bytes.Buffer{}), "generates the constant DPolicyRule, which is already declared in package")
 }
 
 func TestBuilderAliasPrimitiveTypeNotGenerated(t *testing.T) {
@@ -171,18 +331,177 @@ func TestBuilderPattern_GenerateSettersForType(t *testing.T) {
 	// ObjectMeta setters
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithName(in string) *CDeployment")
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithLabels(in map[string]string) *CDeployment")
+	// RemoveLabel deletes the keys of the labels
+	assert.NotContains(t, buf.String(), "DeleteLabels")
 	assert.NotContains(t, buf.String(), "AppendFinalizers")
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithIntPtr(in int) *CDeployment")
 	// Spec setters
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithSpec(in *MockSpec) *CDeployment")
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithPointerSpec(in *MockSpec) *CDeployment")
 	assert.Contains(t, buf.String(), "func (o *CDeployment) AppendSpecs(in ...*MockSpec) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) SetSpecs(in ...*MockSpec) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) ClearSpecs() *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) RemoveSpecs(predicate func(cd.MockSpec) bool) *CDeployment")
 	assert.NotContains(t, buf.String(), "SpecNoGen")
 	assert.NotContains(t, buf.String(), "PointerSpecNoGen")
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithPrimitive(in int) *CDeployment")
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithBool(in ...bool) *CDeployment")
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithPointerBool(in ...bool) *CDeployment")
 	assert.Contains(t, buf.String(), "func (o *CDeployment) WithMapStringByteSlice(in map[string][]byte) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) PutMapStringByteSlice(key string, value []byte) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) DeleteMapStringByteSlice(keys ...string) *CDeployment")
+	// Map setters for indexed values
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithSpecsByName(key string, in *MockSpec) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) DeleteSpecsByName(keys ...string) *CDeployment")
+	assert.NotContains(t, buf.String(), "PutSpecsByName")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithPointerSpecsByID(key int, in *MockSpec) *CDeployment")
+}
+
+func TestBuilderPattern_MemberMarkers(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "c", "CDeployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+
+	assert.NotContains(t, buf.String(), "Skipped")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithImageRef(in string) *CDeployment")
+	assert.Contains(t, buf.String(), "o.MockDeployment.Image = in")
+	assert.NotContains(t, buf.String(), "WithImage(")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithForcedReadOnly(in string) *CDeployment")
+}
+
+func TestBuilderPattern_SkipAndIncludeArgs(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "c", "SDeployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+
+	assert.NotContains(t, buf.String(), "WithPrimitive")
+	assert.NotContains(t, buf.String(), "WithLabels")
+	assert.NotContains(t, buf.String(), "WithLabel(")
+	assert.Contains(t, buf.String(), "func (o *SDeployment) WithName(in string) *SDeployment")
+	assert.Contains(t, buf.String(), "func (o *SDeployment) AppendFinalizers(in ...string) *SDeployment")
+	assert.Contains(t, buf.String(), "func (o *SDeployment) WithSpec(in *MockSpec) *SDeployment")
+}
+
+func TestBuilderPattern_GenerateGettersForType(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "c", "GDeployment")
+	_, specTypeToGenerate := newTestGeneratorType(t, "c", "MockSpec")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.True(t, g.Filter(c, typeToGenerate))
+	assert.True(t, g.Filter(c, specTypeToGenerate))
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+
+	// ObjectMeta getters
+	assert.NotContains(t, buf.String(), "func (o *GDeployment) GetName()")
+	assert.Contains(t, buf.String(), "func (o *GDeployment) GetLabels() (out map[string]string)")
+	assert.Contains(t, buf.String(), "func (o *GDeployment) GetIntPtr() (out int)")
+	assert.NotContains(t, buf.String(), "GetFinalizers")
+	// Spec getters
+	assert.Contains(t, buf.String(), "func (o *GDeployment) GetSpec() *MockSpec")
+	assert.Contains(t, buf.String(), "func (o *GDeployment) GetPointerSpec() *MockSpec")
+	assert.Contains(t, buf.String(), "func (o *GDeployment) GetSpecs() (out []*MockSpec)")
+	assert.NotContains(t, buf.String(), "GetSpecNoGen")
+	assert.Contains(t, buf.String(), "func (o *GDeployment) GetPrimitive() (out int)")
+	assert.Contains(t, buf.String(), "func (o *GDeployment) GetBool() (out bool)")
+	assert.Contains(t, buf.String(), "func (o *GDeployment) GetPointerBool() (out bool)")
+	assert.Contains(t, buf.String(), "func (o *GDeployment) GetMapStringByteSlice() (out map[string][]byte)")
+}
+
+func TestBuilderPattern_GettersNotGeneratedByDefault(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "c", "CDeployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+	assert.NotContains(t, buf.String(), "func (o *CDeployment) Get")
+}
+
+func TestBuilderPattern_ImmutableSetters(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "c", "IDeployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+
+	assert.Contains(t, buf.String(), "func (in *IDeployment) DeepCopy() *IDeployment")
+	assert.Contains(t, buf.String(), `func (in *IDeployment) DeepCopyInto(out *IDeployment) {
+	in.MockDeployment.DeepCopyInto(&out.MockDeployment)
+}`)
+	assert.Contains(t, buf.String(), `func (o *IDeployment) WithName(in string) *IDeployment {
+	o = o.DeepCopy()
+	o.ObjectMeta.Name = in
+	return o
+}`)
+	assert.Contains(t, buf.String(), `func (o *IDeployment) WithPrimitive(in int) *IDeployment {
+	o = o.DeepCopy()
+`)
+}
+
+func TestBuilderPattern_ImmutableRequiresDeepCopy(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "d", "IPolicyRule")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.ErrorContains(t, g.GenerateType(c, typeToGenerate, buf), "immutable requires the parent type to implement DeepCopyInto")
+}
+
+func TestBuilderPattern_WrapConstructors(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "c", "CDeployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+
+	assert.Contains(t, buf.String(), `func CDeploymentFrom(obj *cd.MockDeployment) *CDeployment {
+	if obj == nil {
+		return nil
+	}
+	o := &CDeployment{}
+	obj.DeepCopyInto(&o.MockDeployment)
+	return o
+}`)
+	assert.Contains(t, buf.String(), "func WrapCDeployment(obj *cd.MockDeployment) *CDeployment {")
+	assert.Contains(t, buf.String(), "return &CDeployment{MockDeployment: *obj}")
+
+	// deep copies require DeepCopyInto on the parent type
+	pkg, typeToGenerate = newTestGeneratorType(t, "d", "DPolicyRule")
+	g = b.NewBuilder(pkg, defaultIndex)
+	buf = &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.NotContains(t, buf.String(), "DPolicyRuleFrom")
+	assert.Contains(t, buf.String(), "func WrapDPolicyRule(obj *")
+}
+
+func TestBuilderPattern_RuntimeObject(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "c", "CDeployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+
+	assert.Contains(t, buf.String(), "func (in *CDeployment) DeepCopyObject() pkgruntime.Object {")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) ToUnstructured() (map[string]interface{}, error) {")
+	assert.Contains(t, buf.String(), "pkgruntime.DefaultUnstructuredConverter.ToUnstructured(&o.MockDeployment)")
+	assert.Contains(t, buf.String(), "func CDeploymentFromUnstructured(content map[string]interface{}) (*CDeployment, error) {")
+
+	// only types embedding ObjectMeta are objects
+	pkg, typeToGenerate = newTestGeneratorType(t, "d", "DPolicyRule")
+	g = b.NewBuilder(pkg, defaultIndex)
+	buf = &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.NotContains(t, buf.String(), "Unstructured")
+	assert.NotContains(t, buf.String(), "DeepCopyObject")
 }
 
 func TestBuilderPattern_ObjectMetaGeneratesImportLines(t *testing.T) {
@@ -193,9 +512,10 @@ func TestBuilderPattern_ObjectMetaGeneratesImportLines(t *testing.T) {
 	assert.NoError(t, g.GenerateType(c, typeToGenerate, &bytes.Buffer{}))
 
 	imports := g.Imports(c)
-	assert.Len(t, imports, 4) // 4 types are tagged for importing
+	assert.Len(t, imports, 6) // 4 types are tagged for importing, Validate uses the field package and WithOwner and the runtime.Object helpers the runtime package
 	assert.Contains(t, strings.Join(imports, ""), "cmeta")
 	assert.Contains(t, strings.Join(imports, ""), "cd")
+	assert.Contains(t, strings.Join(imports, ""), "\"k8s.io/apimachinery/pkg/runtime\"")
 
 }
 
@@ -207,4 +527,821 @@ func TestBuilderPattern_GenerateInit(t *testing.T) {
 	c := newGeneratorContext(g)
 	assert.NoError(t, g.Init(c, buf))
 	assert.Contains(t, buf.String(), "mergeMapStringString")
+	assert.NotContains(t, buf.String(), "RenderAll")
+}
+
+func TestBuilderPattern_Render(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "f", "RenderedWidget")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.Init(c, buf))
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+
+	assert.Contains(t, buf.String(), "func renderJSON(content map[string]interface{}) ([]byte, error) {")
+	assert.Contains(t, buf.String(), "func RenderAll(objs ...interface{ ToYAML() ([]byte, error) }) ([]byte, error) {")
+	assert.Contains(t, buf.String(), "func (o *RenderedWidget) ToJSON() ([]byte, error) {")
+	assert.Contains(t, buf.String(), "func (o *RenderedWidget) ToYAML() ([]byte, error) {")
+	assert.Contains(t, buf.String(), "return sigsk8sioyaml.JSONToYAML(data)")
+
+	// render is opt-in
+	pkg, typeToGenerate = newTestGeneratorType(t, "f", "Widget")
+	g = b.NewBuilder(pkg, defaultIndex)
+	buf = &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.NotContains(t, buf.String(), "ToYAML")
+	// wrappers synthesized by deep roots are generated in the package
+	pkg, typeToGenerate = newTestGeneratorType(t, "g", "Widget")
+	packageIndex := generators.NewPackageTypeIndex()
+	packageIndex.SyntheticTypesByPackage[pkg.Path] = []*types.Type{{
+		Name:         types.Name{Package: pkg.Path, Name: "RenderedWidget"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+kanopy:builder=true,render=true"},
+		Members:      typeToGenerate.Members,
+	}}
+	g = b.NewBuilder(pkg, packageIndex)
+	buf = &bytes.Buffer{}
+	assert.NoError(t, g.Init(newGeneratorContext(g), buf))
+	assert.Contains(t, buf.String(), "func renderJSON(content map[string]interface{}) ([]byte, error) {")
+}
+
+func TestBuilderPattern_DeepTypes(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "e", "Cluster")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+	assert.NoError(t, g.Finalize(c, buf))
+
+	// root setters use the synthesized wrappers
+	assert.Contains(t, buf.String(), "func (o *Cluster) WithName(in string) *Cluster")
+	assert.Contains(t, buf.String(), "func (o *Cluster) WithSpec(in *ClusterSpec) *Cluster")
+	// synthesized wrappers
+	assert.Contains(t, buf.String(), `type ClusterSpec struct {
+	eapi.ClusterSpec
+}`)
+	assert.Contains(t, buf.String(), "func NewClusterSpec() *ClusterSpec")
+	assert.Contains(t, buf.String(), "func (o *ClusterSpec) AppendNodes(in ...*Node) *ClusterSpec")
+	assert.Contains(t, buf.String(), "func (o *Node) WithConfig(in *NodeConfig) *Node")
+	// cycles
+	assert.Contains(t, buf.String(), "func (o *ClusterSpec) WithParent(in *ClusterSpec) *ClusterSpec")
+	assert.Contains(t, buf.String(), "func (o *NodeConfig) AppendNodes(in ...*Node) *NodeConfig")
+	assert.Equal(t, 1, strings.Count(buf.String(), "type Node struct"))
+	// explicit wrappers take precedence
+	assert.Contains(t, buf.String(), "func (o *ClusterSpec) WithTemplate(in *Template) *ClusterSpec")
+	assert.NotContains(t, buf.String(), "type NodeTemplate struct")
+	// name collisions are prefixed with the package name
+	assert.Contains(t, buf.String(), "func (o *ClusterSpec) WithPools(key string, in *ApiPool) *ClusterSpec")
+	assert.Contains(t, buf.String(), "func (o *ApiPool) WithSize(in int) *ApiPool")
+	// ObjectMeta is never wrapped
+	assert.NotContains(t, buf.String(), "type ObjectMeta struct")
+	assert.NotContains(t, buf.String(), "WithMetadata")
+}
+
+func TestBuilderPattern_ApplyConfiguration(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "h", "Cluster")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.Init(c, buf))
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+	assert.NoError(t, g.Finalize(c, buf))
+
+	// ObjectMeta only holds the members set by clients
+	assert.Contains(t, buf.String(), `type ObjectMetaApplyConfiguration struct {
+	Name *string `+"`json:\"Name,omitempty\"`"+`
+	Namespace *string `+"`json:\"Namespace,omitempty\"`"+`
+	Labels map[string]string `+"`json:\"Labels,omitempty\"`"+`
+	Annotations map[string]string `+"`json:\"Annotations,omitempty\"`"+`
+	OwnerReferences []cmeta.OwnerReference `+"`json:\"OwnerReferences,omitempty\"`"+`
+	Finalizers []string `+"`json:\"Finalizers,omitempty\"`"+`
+}`)
+	assert.NotContains(t, buf.String(), "WithIntPtr(in int) *ClusterApplyConfiguration")
+	assert.Contains(t, buf.String(), `type ClusterApplyConfiguration struct {
+	cmeta.TypeMeta `+"`json:\",inline\"`"+`
+	ObjectMeta *ObjectMetaApplyConfiguration `+"`json:\"metadata,omitempty\"`"+`
+	Spec *ClusterSpecApplyConfiguration `+"`json:\"spec,omitempty\"`"+`
+}`)
+	assert.Contains(t, buf.String(), "func NewClusterApplyConfiguration(name string) *ClusterApplyConfiguration {")
+	// gvk only applies to the tagged deep root
+	assert.Contains(t, buf.String(), `	o.APIVersion = "example.com/v1"
+	o.Kind = "Cluster"`)
+	assert.Contains(t, buf.String(), "func (o *ClusterApplyConfiguration) WithNamespace(in string) *ClusterApplyConfiguration {")
+	assert.Contains(t, buf.String(), "func (o *ClusterApplyConfiguration) WithSpec(in *ClusterSpecApplyConfiguration) *ClusterApplyConfiguration {")
+	assert.Contains(t, buf.String(), "func (o *ClusterApplyConfiguration) ToUnstructured() (map[string]interface{}, error) {")
+	// deep wrappers inherit the argument
+	assert.Contains(t, buf.String(), "func NewClusterSpecApplyConfiguration() *ClusterSpecApplyConfiguration {")
+	assert.Contains(t, buf.String(), "func (o *ClusterSpec) GetReplicas() (out int32) {")
+	for _, synthetic := range defaultIndex.SyntheticTypesByPackage[pkg.Path] {
+		assert.Equal(t, []string{"+kanopy:builder=true,getters=true,applyconfig=true"}, synthetic.CommentLines)
+	}
+	assert.Contains(t, buf.String(), "Replicas *int32 `json:\"replicas,omitempty\"`")
+	assert.Contains(t, buf.String(), "Nodes []NodeApplyConfiguration `json:\"nodes,omitempty\"`")
+	assert.Contains(t, buf.String(), "func (o *ClusterSpecApplyConfiguration) AppendNodes(in ...*NodeApplyConfiguration) *ClusterSpecApplyConfiguration {")
+	assert.Contains(t, buf.String(), "Template *NodeTemplateApplyConfiguration `json:\"template,omitempty\"`")
+	assert.Contains(t, buf.String(), "Metadata *ObjectMetaApplyConfiguration `json:\"metadata,omitempty\"`")
+	assert.Contains(t, buf.String(), "func (o *NodeTemplateApplyConfiguration) WithImage(in string) *NodeTemplateApplyConfiguration {")
+	// unserialized members are excluded
+	assert.NotContains(t, buf.String(), "Ignored *string")
+
+	pkg, typeToGenerate = newTestGeneratorType(t, "h", "Port")
+	g = b.NewBuilder(pkg, defaultIndex)
+	buf = &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.Contains(t, buf.String(), "Port *int32 `json:\"port,omitempty\"`")
+	assert.Contains(t, buf.String(), "func NewPortApplyConfiguration() *PortApplyConfiguration {")
+	assert.NotContains(t, buf.String(), "Status *string")
+
+	// struct members without an apply configuration would be serialized with their zero fields
+	pkg, typeToGenerate = newTestGeneratorType(t, "h", "Shard")
+	g = b.NewBuilder(pkg, defaultIndex)
+	err := g.GenerateType(newGeneratorContext(g), typeToGenerate, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "member Config has no apply configuration")
+}
+
+func TestBuilderPattern_FinalizeWithoutDeepTypes(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, _ := newTestGeneratorType(t, "c", "CDeployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.Finalize(c, buf))
+	assert.Empty(t, buf.String())
+}
+
+func TestBuilderPattern_MemberConfig(t *testing.T) {
+	config, err := members.LoadConfig("./testdata/members.yaml")
+	assert.NoError(t, err)
+
+	b := &BuilderPatternGeneratorFactory{Members: config}
+	pkg, typeToGenerate := newTestGeneratorType(t, "c", "CDeployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+
+	assert.Contains(t, buf.String(), "func (o *CDeployment) AppendFinalizers(in ...string) *CDeployment")
+	assert.Contains(t, buf.String(), "func (o *CDeployment) WithReadOnlyLowerCase(in int) *CDeployment")
+	assert.NotContains(t, buf.String(), "WithReadOnlyMember")
+	assert.NotContains(t, buf.String(), "WithPrimitive")
+}
+
+func TestBuilderPattern_TypeMeta(t *testing.T) {
+	tests := []struct {
+		description string
+		typeName    string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			description: "type meta from the upstream group name and version",
+			typeName:    "Widget",
+			want: []string{
+				`o.TypeMeta.APIVersion = "example.com/v1"`,
+				`o.TypeMeta.Kind = "Widget"`,
+			},
+		},
+		{
+			description: "type meta from the gvk argument",
+			typeName:    "Gadget",
+			want: []string{
+				`o.TypeMeta.APIVersion = "widgets.example.com/v1beta1"`,
+				`o.TypeMeta.Kind = "Gadget"`,
+			},
+		},
+		{
+			description: "type meta from the SchemeGroupVersion of the upstream package",
+			typeName:    "Sprocket",
+			want: []string{
+				`o.TypeMeta.APIVersion = "sprockets.example.com/v1beta1"`,
+				`o.TypeMeta.Kind = "Sprocket"`,
+			},
+		},
+		{
+			description: "type meta from the +groupName tag of a kubebuilder groupversion_info.go",
+			typeName:    "Gizmo",
+			want: []string{
+				`o.TypeMeta.APIVersion = "gizmos.example.com/v2"`,
+				`o.TypeMeta.Kind = "Gizmo"`,
+			},
+		},
+		{
+			description: "type meta from the GroupVersion of the upstream package",
+			typeName:    "GroupVersionGizmo",
+			want: []string{
+				`o.TypeMeta.APIVersion = "gadgets.example.com/v3"`,
+				`o.TypeMeta.Kind = "Gizmo"`,
+			},
+		},
+		{
+			description: "no type meta without a group",
+			typeName:    "AlphaSprocket",
+		},
+		{
+			description: "no type meta without an embedded TypeMeta",
+			typeName:    "WidgetTemplate",
+		},
+		{
+			description: "gvk argument without an embedded TypeMeta",
+			typeName:    "InvalidGVK",
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		b := &BuilderPatternGeneratorFactory{}
+		pkg, typeToGenerate := newTestGeneratorType(t, "f", test.typeName)
+		g := b.NewBuilder(pkg, defaultIndex)
+		buf := &bytes.Buffer{}
+		c := newGeneratorContext(g)
+		err := g.GenerateType(c, typeToGenerate, buf)
+		if test.wantErr {
+			assert.Error(t, err, test.description)
+			continue
+		}
+
+		assert.NoError(t, err, test.description)
+		assert.Contains(t, buf.String(), fmt.Sprintf("func New%s(name string) *%s", test.typeName, test.typeName), test.description)
+		if len(test.want) == 0 {
+			assert.NotContains(t, buf.String(), "o.TypeMeta", test.description)
+		}
+		for _, want := range test.want {
+			assert.Contains(t, buf.String(), want, test.description)
+		}
+	}
+}
+
+func TestBuilderPattern_RuntimeObjectRequiresTypeMeta(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "f", "WidgetTemplate")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+
+	// types without TypeMeta have no GetObjectKind and are no objects
+	assert.NotContains(t, buf.String(), "DeepCopyObject")
+	assert.Contains(t, buf.String(), "func (o *WidgetTemplate) ToUnstructured() (map[string]interface{}, error) {")
+}
+
+func TestBuilderPattern_OptionsStyle(t *testing.T) {
+	tests := []struct {
+		description string
+		dir         string
+		typeName    string
+		want        []string
+		notWant     []string
+		wantErr     bool
+	}{
+		{
+			description: "options style selected by the package",
+			dir:         "g",
+			typeName:    "Widget",
+			want: []string{
+				"type WidgetOption func(*Widget)",
+				"func NewWidget(name string, opts ...WidgetOption) *Widget {",
+				"func NewWidgetInNamespace(namespace, name string, opts ...WidgetOption) *Widget {",
+				"func WithSize(in int) WidgetOption {\n\treturn func(o *Widget) {\n\t\to.Widget.Size = in\n\t}\n}",
+				"func WithLabel(key string, value string) WidgetOption {",
+				"func WithOwner(owner cmeta.Object, controller bool) WidgetOption {",
+				// Port generates WithName as well
+				"func WidgetWithName(in string) WidgetOption {",
+				"func (o *Widget) HasFinalizer(finalizer string) bool {",
+				"func (o *Widget) Build() (*fv1.Widget, error) {",
+			},
+			notWant: []string{"func (o *Widget) WithSize("},
+		},
+		{
+			description: "options applied after the required members",
+			dir:         "g",
+			typeName:    "Port",
+			want: []string{
+				"func NewPort(port int32, inType string, opts ...PortOption) *Port {",
+				"\to.Port.Type = inType\n\tfor _, opt := range opts {\n\t\topt(o)\n\t}\n\treturn o\n",
+				"func WithProtocol(in string) PortOption {",
+				"func PortWithName(in string) PortOption {",
+			},
+		},
+		{
+			description: "type style overrides the package style",
+			dir:         "g",
+			typeName:    "WidgetTemplate",
+			want: []string{
+				"func NewWidgetTemplate(name string) *WidgetTemplate {",
+				"func (o *WidgetTemplate) WithSize(in int) *WidgetTemplate {",
+			},
+			notWant: []string{"WidgetTemplateOption"},
+		},
+		{
+			description: "unknown style",
+			dir:         "f",
+			typeName:    "InvalidStyle",
+			wantErr:     true,
+		},
+		{
+			description: "option constructor declared in the package",
+			dir:         "f",
+			typeName:    "OptionCollision",
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		b := &BuilderPatternGeneratorFactory{}
+		pkg, typeToGenerate := newTestGeneratorType(t, test.dir, test.typeName)
+		g := b.NewBuilder(pkg, defaultIndex)
+		buf := &bytes.Buffer{}
+		c := newGeneratorContext(g)
+		err := g.GenerateType(c, typeToGenerate, buf)
+		if test.wantErr {
+			assert.Error(t, err, test.description)
+			continue
+		}
+
+		assert.NoError(t, err, test.description)
+		for _, want := range test.want {
+			assert.Contains(t, buf.String(), want, test.description)
+		}
+		for _, notWant := range test.notWant {
+			assert.NotContains(t, buf.String(), notWant, test.description)
+		}
+	}
+}
+
+func TestBuilderPattern_ConstructorInNamespace(t *testing.T) {
+	tests := []struct {
+		description string
+		dir         string
+		typeName    string
+		want        bool
+	}{
+		{
+			description: "upstream type tagged genclient",
+			dir:         "f",
+			typeName:    "Widget",
+			want:        true,
+		},
+		{
+			description: "upstream type tagged genclient:nonNamespaced",
+			dir:         "f",
+			typeName:    "WidgetClass",
+		},
+		{
+			description: "namespaced argument set to false",
+			dir:         "f",
+			typeName:    "Gadget",
+		},
+		{
+			description: "namespaced argument set to true",
+			dir:         "f",
+			typeName:    "NamespacedTemplate",
+			want:        true,
+		},
+		{
+			description: "upstream type without genclient tags",
+			dir:         "c",
+			typeName:    "CDeployment",
+		},
+	}
+
+	for _, test := range tests {
+		b := &BuilderPatternGeneratorFactory{}
+		pkg, typeToGenerate := newTestGeneratorType(t, test.dir, test.typeName)
+		g := b.NewBuilder(pkg, defaultIndex)
+		buf := &bytes.Buffer{}
+		c := newGeneratorContext(g)
+		assert.NoError(t, g.GenerateType(c, typeToGenerate, buf), test.description)
+
+		constructor := fmt.Sprintf("func New%sInNamespace(namespace, name string) *%s", test.typeName, test.typeName)
+		if test.want {
+			assert.Contains(t, buf.String(), constructor, test.description)
+		} else {
+			assert.NotContains(t, buf.String(), constructor, test.description)
+		}
+	}
+}
+
+func TestBuilderPattern_ConstructorWithRequired(t *testing.T) {
+	tests := []struct {
+		description string
+		typeName    string
+		want        []string
+		wantErr     string
+	}{
+		{
+			description: "members that are not optional",
+			typeName:    "Port",
+			want: []string{
+				"func NewPort(port int32, inType string) *Port",
+				"o.Port.Port = port",
+				"o.Port.Type = inType",
+			},
+		},
+		{
+			description: "listed members in parameter order",
+			typeName:    "OrderedPort",
+			want: []string{
+				"func NewOrderedPort(protocol string, port int32) *OrderedPort",
+				"o.Port.Protocol = protocol",
+				"o.Port.Port = port",
+			},
+		},
+		{
+			description: "unknown members",
+			typeName:    "UnknownRequiredPort",
+			wantErr:     "required lists Ports which is not a member of",
+		},
+		{
+			description: "duplicate members",
+			typeName:    "DuplicateRequiredPort",
+			wantErr:     "required lists member Port more than once",
+		},
+	}
+
+	for _, test := range tests {
+		b := &BuilderPatternGeneratorFactory{}
+		pkg, typeToGenerate := newTestGeneratorType(t, "f", test.typeName)
+		g := b.NewBuilder(pkg, defaultIndex)
+		buf := &bytes.Buffer{}
+		c := newGeneratorContext(g)
+		err := g.GenerateType(c, typeToGenerate, buf)
+		if test.wantErr != "" {
+			assert.ErrorContains(t, err, test.wantErr, test.description)
+			continue
+		}
+		assert.NoError(t, err, test.description)
+
+		assert.NotContains(t, buf.String(), fmt.Sprintf("func New%s() *%s", test.typeName, test.typeName), test.description)
+		for _, want := range test.want {
+			assert.Contains(t, buf.String(), want, test.description)
+		}
+	}
+}
+
+func TestBuilderPattern_Templates(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{Templates: snippets.Templates{
+		"EmptyConstructor": "// Create$.type|raw$ returns an empty builder.\nfunc Create$.type|raw$() *$.type|raw$ {\n\treturn &$.type|raw${}\n}\n\n",
+	}}
+	pkg, typeToGenerate := newTestGeneratorType(t, "a", "AStruct")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.Contains(t, buf.String(), "func CreateAStruct() *AStruct {")
+	assert.NotContains(t, buf.String(), "func NewAStruct() *AStruct {")
+
+	// templates belong to the generator
+	g = (&BuilderPatternGeneratorFactory{}).NewBuilder(pkg, defaultIndex)
+	buf = &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.Contains(t, buf.String(), "func NewAStruct() *AStruct {")
+}
+
+func TestBuilderPattern_UpstreamDeepCopy(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "i", "Deployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.Contains(t, buf.String(), "// Build is an autogenerated function that validates and returns a deep copy of the underlying appsv1.Deployment.")
+	assert.Contains(t, buf.String(), "\tout := new(appsv1.Deployment)\n\to.Deployment.DeepCopyInto(out)\n\treturn out, nil\n")
+}
+
+func TestBuilderPattern_UpstreamImmutable(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "i", "Container")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.Contains(t, buf.String(), "func (in *Container) DeepCopyInto(out *Container) {")
+	assert.Contains(t, buf.String(), "func (o *Container) WithImage(in string) *Container {\n\to = o.DeepCopy()\n")
+}
+
+func TestBuilderPattern_UpstreamConstructorFrom(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "i", "Container")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.Contains(t, buf.String(), `func ContainerFrom(obj *corev1.Container) *Container {
+	if obj == nil {
+		return nil
+	}
+	o := &Container{}
+	obj.DeepCopyInto(&o.Container)
+	return o
+}`)
+}
+
+func TestBuilderPattern_ScaffoldRenamedSlicePointer(t *testing.T) {
+	// testdata/j is the scaffold of NodeConfig and of Node renamed to ClusterNode
+	c := &scaffold.Config{Package: "j", Types: []scaffold.Type{
+		{Ref: "github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/e/api.NodeConfig"},
+		{Ref: "github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/e/api.Node", Name: "ClusterNode"},
+	}}
+	files, err := scaffold.Render(c)
+	require.NoError(t, err)
+	for name, content := range files {
+		onDisk, err := os.ReadFile(filepath.Join("testdata", "j", name))
+		require.NoError(t, err)
+		assert.Equal(t, string(content), string(onDisk))
+	}
+
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "j", "NodeConfig")
+	// testdata/e synthesizes a Node wrapper in the shared index
+	packageIndex := generators.NewPackageTypeIndex()
+	indexPackage(packageIndex, pkg)
+	g := b.NewBuilder(pkg, packageIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+
+	assert.Contains(t, buf.String(), "func (o *NodeConfig) AppendNodes(in ...*ClusterNode) *NodeConfig")
+	assert.Contains(t, buf.String(), "o.NodeConfig.Nodes = append(o.NodeConfig.Nodes, &elem.Node)")
+	assert.NotContains(t, buf.String(), "elem.ClusterNode")
+}
+
+func TestBuilderPattern_UpstreamTypeMeta(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "i", "Deployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+
+	// k8s.io/api/apps/v1 has no +groupName tag but a GroupName constant
+	assert.Contains(t, buf.String(), `o.TypeMeta.APIVersion = "apps/v1"`)
+	assert.Contains(t, buf.String(), `o.TypeMeta.Kind = "Deployment"`)
+}
+
+func TestBuilderPattern_UpstreamDeepOptions(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "k", "PodSpec")
+	g := b.NewBuilder(pkg, defaultIndex)
+	c := newGeneratorContext(g)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+	assert.NoError(t, g.Finalize(c, buf))
+	// the wrapper of corev1.PodDNSConfigOption leaves the name to the option type of PodDNSConfig
+	assert.Contains(t, buf.String(), "type PodDNSConfigOption func(*PodDNSConfig)")
+	assert.Contains(t, buf.String(), "type V1PodDNSConfigOption struct {")
+	assert.Contains(t, buf.String(), "func AppendOptions(in ...*V1PodDNSConfigOption) PodDNSConfigOption {")
+	// setters generated for several types are prefixed with the type name
+	assert.Contains(t, buf.String(), "func VolumeWithName(in string) VolumeOption {")
+
+	// the shared index already maps the upstream types to the wrappers of testdata/k
+	pkg, typeToGenerate = newTestGeneratorType(t, "k/renamed", "PodSpec")
+	packageIndex := generators.NewPackageTypeIndex()
+	indexPackage(packageIndex, pkg)
+	g = b.NewBuilder(pkg, packageIndex)
+	c = newGeneratorContext(g)
+	buf = &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+	assert.NoError(t, g.Finalize(c, buf))
+
+	assert.Contains(t, buf.String(), "type PodDNSConfigOption func(*PodDNSConfig)")
+	assert.Contains(t, buf.String(), "func AppendOptions(in ...*DNSConfigOption) PodDNSConfigOption")
+	assert.Equal(t, 1, strings.Count(buf.String(), "type PodDNSConfigOption "))
+}
+
+func TestBuilderPattern_UpstreamRender(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "i", "Service")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.Init(c, buf))
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+
+	assert.Contains(t, buf.String(), `o.TypeMeta.APIVersion = "v1"`)
+	assert.Contains(t, buf.String(), `o.TypeMeta.Kind = "Service"`)
+	assert.Contains(t, buf.String(), "func (o *Service) ToYAML() ([]byte, error) {")
+	// the status of a new Service is {loadBalancer: {}}
+	assert.Contains(t, buf.String(), `	if isEmptyStatus(content["status"]) {
+		delete(content, "status")
+	}`)
+	assert.Contains(t, buf.String(), "func isEmptyStatus(value interface{}) bool {")
+}
+
+func TestBuilderPattern_RenamedRuntimeObject(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "l", "MyDeployment")
+	g := b.NewBuilder(pkg, defaultIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+
+	// renamed wrappers copy the embedded parent by its field name
+	assert.Contains(t, buf.String(), "func (in *MyDeployment) DeepCopyObject() pkgruntime.Object {")
+	assert.Contains(t, buf.String(), `func (in *MyDeployment) DeepCopyInto(out *MyDeployment) {
+	in.Deployment.DeepCopyInto(&out.Deployment)
+}`)
+}
+
+// assertGeneratedPackageCompiles generates every type of testdata/dir, then vets the package with the generated file and runs tests against it.
+// tests is the source of a test file of the package, it is skipped if empty.
+func assertGeneratedPackageCompiles(t *testing.T, dir string, tests string) {
+	// the generated file imports the other packages by path, so the package is loaded by its import path too
+	pkgPath := "github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/" + dir
+	d := args.Default()
+	d.InputDirs = []string{pkgPath}
+	p, err := d.NewBuilder()
+	require.NoError(t, err)
+	universe, err := p.FindTypes()
+	require.NoError(t, err)
+	pkg := universe[pkgPath]
+	require.NotNil(t, pkg)
+
+	packageIndex := generators.NewPackageTypeIndex()
+	indexPackage(packageIndex, pkg)
+
+	b := &BuilderPatternGeneratorFactory{}
+	g := b.NewBuilder(pkg, packageIndex)
+	c := newGeneratorContext(g)
+	body := &bytes.Buffer{}
+	require.NoError(t, g.Init(c, body))
+
+	names := make([]string, 0, len(pkg.Types))
+	for name := range pkg.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if typ := pkg.Types[name]; g.Filter(c, typ) {
+			require.NoError(t, g.GenerateType(c, typ, body))
+		}
+	}
+	require.NoError(t, g.Finalize(c, body))
+
+	src := &bytes.Buffer{}
+	fmt.Fprintf(src, "package %s\n\nimport (\n", pkg.Name)
+	for _, line := range g.Imports(c) {
+		fmt.Fprintf(src, "\t%s\n", line)
+	}
+	fmt.Fprintf(src, ")\n\n%s", body.String())
+	// gengo formats generated files with goimports, which drops unused imports such as the package itself
+	formatted, err := imports.Process("zz_generated_builders.go", src.Bytes(), nil)
+	require.NoError(t, err, src.String())
+
+	tmp := t.TempDir()
+	replace := map[string]string{}
+	for name, content := range map[string]string{"zz_generated_builders.go": string(formatted), "zz_generated_builders_test.go": tests} {
+		if content == "" {
+			continue
+		}
+		generated := filepath.Join(tmp, name)
+		require.NoError(t, os.WriteFile(generated, []byte(content), 0o600))
+		target, err := filepath.Abs(filepath.Join("testdata", dir, name))
+		require.NoError(t, err)
+		replace[target] = generated
+	}
+	overlay := filepath.Join(tmp, "overlay.json")
+	data, err := json.Marshal(map[string]interface{}{"Replace": replace})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(overlay, data, 0o600))
+
+	out, err := exec.Command("go", "vet", "-overlay", overlay, "./testdata/"+dir).CombinedOutput()
+	require.NoError(t, err, "%s\n%s", out, formatted)
+	if tests != "" {
+		out, err = exec.Command("go", "test", "-count=1", "-overlay", overlay, "./testdata/"+dir).CombinedOutput()
+		assert.NoError(t, err, "%s", out)
+	}
+}
+
+func TestBuilderPattern_GeneratedCodeCompiles(t *testing.T) {
+	// deep in e, the options style in g and k, with deep in k
+	for _, dir := range []string{"e", "g", "k", "k/renamed", "l"} {
+		assertGeneratedPackageCompiles(t, dir, "")
+	}
+}
+
+func TestBuilderPattern_RenderRoundTrip(t *testing.T) {
+	assertGeneratedPackageCompiles(t, "i", `package i
+
+import (
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRender(t *testing.T) {
+	svc := NewService("web").WithLabels(map[string]string{"app": "web"})
+
+	out, err := svc.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `+"`"+`{"apiVersion":"v1","kind":"Service","metadata":{"labels":{"app":"web"},"name":"web"},"spec":{}}`+"`"+`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+
+	parsed := corev1.Service{}
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Name != "web" || parsed.Labels["app"] != "web" || parsed.Kind != "Service" {
+		t.Errorf("unexpected round trip %+v", parsed)
+	}
+
+	all, err := RenderAll(svc, NewService("db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "apiVersion: v1\nkind: Service\nmetadata:\n  labels:\n    app: web\n  name: web\nspec: {}\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: db\nspec: {}\n"
+	if string(all) != expected {
+		t.Errorf("expected %q, got %q", expected, all)
+	}
+}
+`)
+}
+
+func TestBuilderPattern_ApplyConfigurationMarshalsAtomicMembers(t *testing.T) {
+	assertGeneratedPackageCompiles(t, "m", `package m
+
+import (
+	"encoding/json"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestApplyConfigurationJSON(t *testing.T) {
+	strategy := NewDeploymentStrategyApplyConfiguration().
+		WithType("RollingUpdate").
+		WithRollingUpdate(NewRollingUpdateDeploymentApplyConfiguration().WithMaxSurge(intstr.FromString("25%")))
+	apply := NewDeploymentApplyConfiguration("app").WithSpec(NewDeploymentSpecApplyConfiguration().WithStrategy(strategy))
+
+	out, err := json.Marshal(apply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `+"`"+`{"kind":"Deployment","apiVersion":"apps/v1","metadata":{"name":"app"},"spec":{"strategy":{"type":"RollingUpdate","rollingUpdate":{"maxSurge":"25%"}}}}`+"`"+`
+	if string(out) != expected {
+		t.Errorf("expected %s, got %s", expected, out)
+	}
+}
+`)
+}
+
+func TestBuilderPattern_DeepUpstreamTypes(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "m", "Deployment")
+	packageIndex := generators.NewPackageTypeIndex()
+	indexPackage(packageIndex, pkg)
+	g := b.NewBuilder(pkg, packageIndex)
+	buf := &bytes.Buffer{}
+	c := newGeneratorContext(g)
+	assert.NoError(t, g.GenerateType(c, typeToGenerate, buf))
+	assert.NoError(t, g.Finalize(c, buf))
+
+	// types marshaling themselves are set as a whole
+	assert.NotContains(t, buf.String(), "type IntOrString struct")
+	assert.NotContains(t, buf.String(), "type Quantity struct")
+	assert.Contains(t, buf.String(), "func (o *RollingUpdateDeployment) WithMaxSurge(in *utilintstr.IntOrString) *RollingUpdateDeployment")
+	// members of inline structs are promoted
+	assert.Contains(t, buf.String(), "func (o *Probe) WithExec(in *ExecAction) *Probe")
+	assert.Contains(t, buf.String(), "func (o *Probe) WithHTTPGet(in *HTTPGetAction) *Probe")
+	assert.Contains(t, buf.String(), "func (o *Probe) WithTCPSocket(in *TCPSocketAction) *Probe")
+	assert.Contains(t, buf.String(), "func (o *Volume) WithConfigMap(in *ConfigMapVolumeSource) *Volume")
+	assert.Contains(t, buf.String(), "func (o *Volume) WithSecret(in *SecretVolumeSource) *Volume")
+	assert.Contains(t, buf.String(), "Exec *ExecActionApplyConfiguration `json:\"exec,omitempty\"`")
+	assert.NotContains(t, buf.String(), "type ProbeHandler struct")
+	assert.NotContains(t, buf.String(), "type VolumeSource struct")
+}
+
+func TestBuilderPattern_TypeArgsOverridePackage(t *testing.T) {
+	b := &BuilderPatternGeneratorFactory{}
+	pkg, typeToGenerate := newTestGeneratorType(t, "n", "Container")
+	packageIndex := generators.NewPackageTypeIndex()
+	indexPackage(packageIndex, pkg)
+	g := b.NewBuilder(pkg, packageIndex)
+	buf := &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), typeToGenerate, buf))
+	assert.NotContains(t, buf.String(), "func (o *Container) Get")
+	assert.Contains(t, buf.String(), `func (o *Container) WithName(in string) *Container {
+	o.Container.Name = in
+	return o
+}`)
+
+	buf = &bytes.Buffer{}
+	assert.NoError(t, g.GenerateType(newGeneratorContext(g), pkg.Types["ContainerPort"], buf))
+	assert.Contains(t, buf.String(), "func (o *ContainerPort) GetName() (out string)")
+	assert.Contains(t, buf.String(), `func (o *ContainerPort) WithName(in string) *ContainerPort {
+	o = o.DeepCopy()
+`)
+}
+
+func TestBuilderPattern_DeepImmutable(t *testing.T) {
+	pkg, _ := newTestGeneratorType(t, "o", "CronJob")
+	_, synthetic := index.BuildDeepPackageIndex(map[string]*types.Type{}, pkg)
+	comments := map[string][]string{}
+	for _, typ := range synthetic {
+		comments[typ.Name.Name] = typ.CommentLines
+	}
+	assert.Equal(t, []string{"+kanopy:builder=true,immutable=true"}, comments["CronJobSpec"])
+	// copy on write requires DeepCopyInto
+	assert.Equal(t, []string{"+kanopy:builder=true"}, comments["Window"])
+
+	assertGeneratedPackageCompiles(t, "o", "")
 }
@@ -1,6 +1,11 @@
 package builder
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kanopy-platform/code-generator/pkg/generators/members"
 	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/gengo/namer"
@@ -11,28 +16,118 @@ const (
 	ObjectMeta = "ObjectMeta"
 )
 
-func includeMember(parent *types.Type, member types.Member) bool {
-	log.Debugf("includeMember Check %v", member.Name)
-	if tags.IsMemberReadyOnly(member) {
-		log.Debugf("\t member %v is readonly", member.Name)
+// includeMemberOfRoot applies the skip and include arguments of the root type before the member markers and defaults.
+// Arguments list member names, optionally qualified by the parent type name, e.g. skip=Finalizers;PodSpec.NodeName.
+func includeMemberOfRoot(config *members.Config, root *types.Type, parent *types.Type, member types.Member) bool {
+	if namer.IsPrivateGoName(member.Name) {
+		return false
+	}
+
+	if matchesMember(tags.GetTypeArgList(root, tags.SkipFlag), parent, member) {
+		log.Debugf("\t member %v is skipped by %v", member.Name, root.Name)
 		return false
 	}
 
+	if matchesMember(tags.GetTypeArgList(root, tags.IncludeFlag), parent, member) {
+		log.Debugf("\t member %v is included by %v", member.Name, root.Name)
+		return true
+	}
+
+	return includeMember(config, parent, member)
+}
+
+func matchesMember(names []string, parent *types.Type, member types.Member) bool {
+	for _, name := range names {
+		if name == member.Name || name == parent.Name.Name+"."+member.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// includeMember applies the member markers before the rules and read-only patterns of the member config.
+func includeMember(config *members.Config, parent *types.Type, member types.Member) bool {
+	log.Debugf("includeMember Check %v", member.Name)
 	if namer.IsPrivateGoName(member.Name) {
 		log.Debugf("\t member %v is private", member.Name)
 		return false
 	}
 
-	switch parent.Name.Name {
-	case ObjectMeta:
-		log.Debug("\t member has ObjectMeta")
-		return includeObjectMetaMember(member)
-	default:
-		log.Debug("\t included")
+	if tags.IsMemberSkipped(member) {
+		log.Debugf("\t member %v is skipped", member.Name)
+		return false
+	}
+
+	if tags.IsMemberIncluded(member) {
+		log.Debugf("\t member %v is included", member.Name)
 		return true
 	}
+
+	if config.IsExcluded(parent, member) {
+		log.Debugf("\t member %v is excluded by config", member.Name)
+		return false
+	}
+
+	if config.IsIncluded(parent, member) {
+		log.Debugf("\t member %v is included by config", member.Name)
+		return true
+	}
+
+	if config.IsReadOnly(member) {
+		log.Debugf("\t member %v is readonly", member.Name)
+		return false
+	}
+
+	log.Debug("\t included")
+	return true
 }
 
-func includeObjectMetaMember(member types.Member) bool {
-	return member.Name != "Finalizers"
+// requiredMembers returns the members of the parent type that are parameters of the constructor of the root type.
+// Members are listed by the required argument in parameter order, or with required=auto every member that is not optional is required.
+// Listing a member that does not exist or listing a member twice is an error.
+func (b *BuilderPatternGenerator) requiredMembers(root *types.Type) ([]types.Member, error) {
+	parent := getEmbeddedType(root)
+	names := tags.GetTypeArgList(root, tags.RequiredFlag)
+	if parent == nil || len(names) == 0 {
+		return nil, nil
+	}
+
+	required := []types.Member{}
+	if len(names) == 1 && names[0] == tags.RequiredAuto {
+		for _, m := range parent.Members {
+			if !m.Embedded && includeMemberOfRoot(b.members, root, parent, m) && !tags.IsMemberOptional(m) {
+				required = append(required, m)
+			}
+		}
+		return required, nil
+	}
+
+	seen := map[string]bool{}
+	for _, name := range names {
+		found := false
+		for _, m := range parent.Members {
+			if m.Embedded || namer.IsPrivateGoName(m.Name) || !matchesMember([]string{name}, parent, m) {
+				continue
+			}
+			if seen[m.Name] {
+				return nil, fmt.Errorf("type %s: %s lists member %s more than once", root.Name, tags.RequiredFlag, m.Name)
+			}
+			seen[m.Name] = true
+			found = true
+			required = append(required, m)
+		}
+		if !found {
+			return nil, fmt.Errorf("type %s: %s lists %s which is not a member of %s", root.Name, tags.RequiredFlag, name, parent.Name)
+		}
+	}
+	return required, nil
+}
+
+// jsonName returns the serialized name of a member, falling back to the Go name.
+func jsonName(member types.Member) string {
+	name := strings.Split(reflect.StructTag(member.Tags).Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return member.Name
+	}
+	return name
 }
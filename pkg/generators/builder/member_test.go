@@ -3,7 +3,9 @@ package builder
 import (
 	"testing"
 
+	"github.com/kanopy-platform/code-generator/pkg/generators/members"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/gengo/types"
 )
 
 func TestIncludeMember(t *testing.T) {
@@ -37,6 +39,20 @@ func TestIncludeMember(t *testing.T) {
 			member:       "ReadOnlyLowerCase",
 			want:         false,
 		},
+		{
+			description:  "should not include member marked skip",
+			dir:          "c/d",
+			typeSelector: "MockDeployment",
+			member:       "Skipped",
+			want:         false,
+		},
+		{
+			description:  "should include read-only member marked include",
+			dir:          "c/d",
+			typeSelector: "MockDeployment",
+			member:       "ForcedReadOnly",
+			want:         true,
+		},
 		{
 			description:  "should not include private member",
 			dir:          "d/e",
@@ -50,7 +66,51 @@ func TestIncludeMember(t *testing.T) {
 		_, testType := newTestGeneratorType(t, test.dir, test.typeSelector)
 		member := getMemberFromType(testType, test.member)
 		assert.NotEmpty(t, member, test.description)
-		assert.Equal(t, test.want, includeMember(testType, member), test.description)
+		assert.Equal(t, test.want, includeMember(members.Default(), testType, member), test.description)
+	}
+}
+
+func TestIncludeMemberOfRoot(t *testing.T) {
+	t.Parallel()
+
+	_, root := newTestGeneratorType(t, "c", "SDeployment")
+	_, objectMeta := newTestGeneratorType(t, "c/meta", "ObjectMeta")
+	_, deployment := newTestGeneratorType(t, "c/d", "MockDeployment")
+
+	tests := []struct {
+		description string
+		parent      *types.Type
+		member      string
+		want        bool
+	}{
+		{
+			description: "should not include skipped member",
+			parent:      deployment,
+			member:      "Primitive",
+		},
+		{
+			description: "should not include skipped qualified member",
+			parent:      objectMeta,
+			member:      "Labels",
+		},
+		{
+			description: "should include forced member",
+			parent:      objectMeta,
+			member:      "Finalizers",
+			want:        true,
+		},
+		{
+			description: "should include other members",
+			parent:      objectMeta,
+			member:      "Name",
+			want:        true,
+		},
+	}
+
+	for _, test := range tests {
+		member := getMemberFromType(test.parent, test.member)
+		assert.NotEmpty(t, member, test.description)
+		assert.Equal(t, test.want, includeMemberOfRoot(members.Default(), root, test.parent, member), test.description)
 	}
 }
 
@@ -79,6 +139,6 @@ func TestIncludeObjectMetaMember(t *testing.T) {
 	for _, test := range tests {
 		member := getMemberFromType(objectMeta, test.member)
 		assert.NotEmpty(t, member, test.description)
-		assert.Equal(t, test.want, includeObjectMetaMember(member), test.description)
+		assert.Equal(t, test.want, includeMember(members.Default(), objectMeta, member), test.description)
 	}
 }
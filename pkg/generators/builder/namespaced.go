@@ -0,0 +1,29 @@
+package builder
+
+import (
+	"fmt"
+
+	"github.com/kanopy-platform/code-generator/pkg/generators/index"
+	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
+	"k8s.io/gengo/types"
+)
+
+// isNamespaced returns true if the root type wraps a namespaced resource.
+// The namespaced argument takes precedence over the +genclient tags of the upstream type.
+func isNamespaced(t *types.Type) (bool, error) {
+	namespaced, ok, err := tags.ExtractNamespaced(t)
+	if err != nil || ok {
+		return namespaced, err
+	}
+
+	parent := getParentOfEmbeddedType(t, ObjectMeta)
+	if parent == nil {
+		return false, nil
+	}
+
+	upstream, err := index.LoadType(parent.Name)
+	if err != nil {
+		return false, fmt.Errorf("type %s: %w", t.Name, err)
+	}
+	return tags.IsGenClientNamespaced(upstream), nil
+}
@@ -0,0 +1,110 @@
+package builder
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// optionName returns the name of the option constructor of a setter of root, e.g. WithReplicas.
+// While the option names of the package are resolved, the setter name is recorded and returned as is.
+func (b *BuilderPatternGenerator) optionName(root *types.Type) func(funcName string) string {
+	return func(funcName string) string {
+		if b.recordedOptions != nil {
+			b.recordedOptions[root] = append(b.recordedOptions[root], funcName)
+			return funcName
+		}
+		if name, ok := b.optionNames[root][funcName]; ok {
+			return name
+		}
+		return funcName
+	}
+}
+
+// resolveOptionNames names the option constructors of every type of the package generated in the options style.
+// A constructor is named after its setter, e.g. WithReplicas, unless several types of the package generate the setter,
+// which then prefix it with their type name, e.g. DeploymentWithName and ServiceWithName.
+// The setters of every type are recorded by generating the type without output once.
+func (b *BuilderPatternGenerator) resolveOptionNames(c *generator.Context) error {
+	if b.optionNames != nil {
+		return nil
+	}
+	b.optionNames = map[*types.Type]map[string]string{}
+
+	roots := b.optionsStyleTypes()
+	b.recordedOptions = map[*types.Type][]string{}
+	for _, t := range roots {
+		// errors are reported when the type is generated
+		_ = b.generateBuilderForType(b.newSnippetWriter(io.Discard, c), t)
+	}
+	recorded := b.recordedOptions
+	b.recordedOptions = nil
+
+	generatedBy := map[string]int{}
+	for _, t := range roots {
+		for _, funcName := range uniqueStrings(recorded[t]) {
+			generatedBy[funcName]++
+		}
+	}
+
+	owners := map[string]*types.Type{}
+	for _, t := range roots {
+		names := map[string]string{}
+		for _, funcName := range uniqueStrings(recorded[t]) {
+			name := funcName
+			if generatedBy[funcName] > 1 {
+				name = t.Name.Name + funcName
+			}
+			if other, ok := owners[name]; ok {
+				return fmt.Errorf("type %s: option constructor %s is also generated for %s, rename a member with +kanopy:builder:name", t.Name, name, other.Name)
+			}
+			if b.isPackageNameDeclared(name) {
+				return fmt.Errorf("type %s: option constructor %s collides with a declaration of package %s", t.Name, name, b.pkgToBuild.Path)
+			}
+			owners[name] = t
+			names[funcName] = name
+		}
+		b.optionNames[t] = names
+	}
+	return nil
+}
+
+// optionsStyleTypes returns the types generated in the package in the options style, including the wrappers synthesized by deep roots.
+func (b *BuilderPatternGenerator) optionsStyleTypes() []*types.Type {
+	names := []string{}
+	for name, t := range b.pkgToBuild.Types {
+		if b.needsGeneration(t) && !t.IsPrimitive() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	candidates := []*types.Type{}
+	for _, name := range names {
+		candidates = append(candidates, b.pkgToBuild.Types[name])
+	}
+	candidates = append(candidates, b.packageIndex.SyntheticTypesByPackage[b.pkgToBuild.Path]...)
+
+	out := []*types.Type{}
+	for _, t := range candidates {
+		if options, _ := b.isOptionsStyle(t); options {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func uniqueStrings(in []string) []string {
+	seen := map[string]bool{}
+	out := []string{}
+	for _, s := range in {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -13,3 +13,18 @@ type CDeployment struct {
 type MockSpec struct {
 	d.MockSpec
 }
+
+// +kanopy:builder=true,getters=true
+type GDeployment struct {
+	d.MockDeployment
+}
+
+// +kanopy:builder=true,immutable=true
+type IDeployment struct {
+	d.MockDeployment
+}
+
+// +kanopy:builder=true,skip=Primitive;ObjectMeta.Labels,include=Finalizers
+type SDeployment struct {
+	d.MockDeployment
+}
@@ -16,9 +16,24 @@ type MockDeployment struct {
 	Bool               bool
 	PointerBool        *bool
 	MapStringByteSlice map[string][]byte
+	SpecsByName        map[string]MockSpec
+	PointerSpecsByID   map[int]*MockSpec
+	// +kanopy:builder:skip
+	Skipped string
+	// +kanopy:builder:name=WithImageRef
+	Image string
+	// Read-only.
+	// +kanopy:builder:include
+	ForcedReadOnly string
 }
 
 type MockSpec struct {
+	Selector *meta.LabelSelector
+	Template MockTemplate
+}
+
+type MockTemplate struct {
+	meta.ObjectMeta
 }
 
 type MockSpecNoGen struct {
@@ -1,18 +1,60 @@
 package meta
 
+import "k8s.io/apimachinery/pkg/runtime/schema"
+
 // mock TypeMeta
 type TypeMeta struct {
-	Kind string
+	Kind       string
+	APIVersion string
 }
 
 // mock ObjectMeta
 type ObjectMeta struct {
-	Name       string
-	Labels     map[string]string
-	Finalizers []string
-	IntPtr     *int
+	Name            string
+	Namespace       string
+	Labels          map[string]string
+	Annotations     map[string]string
+	Finalizers      []string
+	OwnerReferences []OwnerReference
+	IntPtr          *int
 	// Read-only.
 	ReadOnlyMember *string
 	// Bla bla read-only
 	ReadOnlyLowerCase int
 }
+
+// mock LabelSelector
+type LabelSelector struct {
+	MatchLabels map[string]string
+}
+
+// mock OwnerReference
+type OwnerReference struct {
+	APIVersion         string
+	Kind               string
+	Name               string
+	UID                string
+	Controller         *bool
+	BlockOwnerDeletion *bool
+}
+
+// mock Object
+type Object interface {
+	GetName() string
+	GetUID() string
+}
+
+func (m *ObjectMeta) GetName() string {
+	return m.Name
+}
+
+// mock TypeMeta implements schema.ObjectKind like the upstream TypeMeta
+func (obj *TypeMeta) GetObjectKind() schema.ObjectKind { return obj }
+
+func (obj *TypeMeta) SetGroupVersionKind(gvk schema.GroupVersionKind) {
+	obj.APIVersion, obj.Kind = gvk.ToAPIVersionAndKind()
+}
+
+func (obj *TypeMeta) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(obj.APIVersion, obj.Kind)
+}
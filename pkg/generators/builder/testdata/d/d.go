@@ -9,5 +9,21 @@ type DPolicyRule struct {
 	e.MockPolicyRule
 }
 
+// +kanopy:builder=true
+type DRoleBinding struct {
+	e.MockRoleBinding
+}
+
 // +kanopy:builder=true,ref=github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/d/e.AliasToString
 type AliasType e.AliasToString
+
+// +kanopy:builder=true,immutable=true
+type IPolicyRule struct {
+	e.MockPolicyRule
+}
+
+// +kanopy:builder=true,ref=github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/d/e.Protocol,enum=auto
+type Protocol e.Protocol
+
+// +kanopy:builder=true,ref=github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/d/e.PhaseCode,enum=Pending:0;Running:1;Failed:-1
+type Phase e.PhaseCode
@@ -1,7 +1,8 @@
 package e
 
 type MockPolicyRule struct {
-	Verbs                 []string
+	// +kubebuilder:validation:Required
+	Verbs                 []string `json:"verbs"`
 	ListOfInts            []int
 	AliasType             *AliasToString
 	ToggleAliasWithoutRef *AnotherAlias
@@ -9,5 +10,47 @@ type MockPolicyRule struct {
 }
 
 type PrivateField struct{}
+
+type MockRoleBinding struct {
+	Subject MockSubject  `json:"subject"`
+	RoleRef *MockRoleRef `json:"roleRef,omitempty"`
+	// elements of slices and maps are validated
+	Items    []MockItem           `json:"items,omitempty"`
+	ItemsMap map[string]*MockItem `json:"itemsMap,omitempty"`
+}
+
+type MockItem struct {
+	// +kubebuilder:validation:MinLength=3
+	Key string `json:"key"`
+}
+
+type MockSubject struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+}
+
+type MockRoleRef struct {
+	// +kubebuilder:validation:Enum=Role;ClusterRole
+	Kind string `json:"kind"`
+	// a cycle is not walked twice
+	Parent *MockRoleBinding `json:"parent,omitempty"`
+}
 type AliasToString string
 type AnotherAlias string
+
+type Protocol string
+
+const (
+	ProtocolTCP  Protocol = "TCP"
+	ProtocolUDP  Protocol = "UDP"
+	ProtocolSCTP Protocol = "SCTP"
+	// values are not parsed as enum entries
+	ProtocolCustom Protocol = "Custom:example.com/proto=1"
+	// duplicate values are listed once
+	DefaultProtocol Protocol = "TCP"
+	privateProtocol Protocol = "private"
+
+	NotAProtocol = "plain"
+)
+
+type PhaseCode int32
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/c/meta"
+)
+
+type Cluster struct {
+	meta.TypeMeta
+	meta.ObjectMeta
+	Spec ClusterSpec
+}
+
+type ClusterSpec struct {
+	Nodes    []Node
+	Template *NodeTemplate
+	Pools    map[string]Pool
+	Parent   *ClusterSpec
+	Metadata meta.ObjectMeta
+}
+
+type Node struct {
+	Name   string
+	Config NodeConfig
+}
+
+type NodeConfig struct {
+	Image string
+	Nodes []*Node
+}
+
+type NodeTemplate struct {
+	Image    string
+	Children []NodeTemplate
+}
+
+type Pool struct {
+	Size int
+}
+
+// mock DeepCopyInto, which is generated for upstream types
+func (in *Cluster) DeepCopyInto(out *Cluster) {
+	*out = *in
+}
@@ -0,0 +1,17 @@
+package e
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/e/api"
+)
+
+// +kanopy:builder=true,deep=true
+type Cluster struct {
+	api.Cluster
+}
+
+// +kanopy:builder=true
+type Template struct {
+	api.NodeTemplate
+}
+
+type Pool string
@@ -0,0 +1,97 @@
+package f
+
+import (
+	v1 "github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/f/v1"
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/f/v1alpha1"
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/f/v1beta1"
+	v2 "github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/f/v2"
+	v3 "github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/f/v3"
+)
+
+// +kanopy:builder=true
+type Widget struct {
+	v1.Widget
+}
+
+// +kanopy:builder=true,gvk=widgets.example.com/v1beta1/Gadget,namespaced=false
+type Gadget struct {
+	v1.Widget
+}
+
+// +kanopy:builder=true
+type WidgetTemplate struct {
+	v1.WidgetTemplate
+}
+
+// +kanopy:builder=true,gvk=v1/Gadget
+type InvalidGVK struct {
+	v1.WidgetTemplate
+}
+
+// +kanopy:builder=true
+type WidgetClass struct {
+	v1.WidgetClass
+}
+
+// +kanopy:builder=true,namespaced=true
+type NamespacedTemplate struct {
+	v1.WidgetTemplate
+}
+
+// +kanopy:builder=true,required=auto
+type Port struct {
+	v1.Port
+}
+
+// +kanopy:builder=true,required=Protocol;Port.Port
+type OrderedPort struct {
+	v1.Port
+}
+
+// +kanopy:builder=true,required=Protocol;Ports
+type UnknownRequiredPort struct {
+	v1.Port
+}
+
+// +kanopy:builder=true,required=Port;Port.Port
+type DuplicateRequiredPort struct {
+	v1.Port
+}
+
+// +kanopy:builder=true,style=fluent
+type InvalidStyle struct {
+	v1.WidgetTemplate
+}
+
+// +kanopy:builder=true,style=options
+type OptionCollision struct {
+	v1.WidgetTemplate
+}
+
+// WithSize is also the option constructor of OptionCollision.
+func WithSize() {}
+
+// +kanopy:builder=true,render=true
+type RenderedWidget struct {
+	v1.Widget
+}
+
+// +kanopy:builder=true
+type Sprocket struct {
+	v1beta1.Sprocket
+}
+
+// +kanopy:builder=true
+type AlphaSprocket struct {
+	v1alpha1.Sprocket
+}
+
+// +kanopy:builder=true
+type Gizmo struct {
+	v2.Gizmo
+}
+
+// +kanopy:builder=true
+type GroupVersionGizmo struct {
+	v3.Gizmo
+}
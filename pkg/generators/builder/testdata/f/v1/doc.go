@@ -0,0 +1,3 @@
+// +groupName=example.com
+
+package v1
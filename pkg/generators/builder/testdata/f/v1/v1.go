@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/c/meta"
+)
+
+// +genclient
+
+// Widget is a namespaced resource.
+type Widget struct {
+	meta.TypeMeta
+	meta.ObjectMeta
+	Size int
+}
+
+type WidgetTemplate struct {
+	meta.ObjectMeta
+	Size int
+}
+
+// +genclient
+// +genclient:nonNamespaced
+
+// WidgetClass is a cluster scoped resource.
+type WidgetClass struct {
+	meta.TypeMeta
+	meta.ObjectMeta
+}
+
+type Port struct {
+	// +optional
+	Name     string `json:"name"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol,omitempty"`
+	Type     string `json:"type"`
+	// Read-only.
+	Status string `json:"status"`
+}
+
+// mock DeepCopyInto of the resources, which are generated for upstream types
+func (in *Widget) DeepCopyInto(out *Widget) {
+	*out = *in
+}
+
+func (in *WidgetTemplate) DeepCopyInto(out *WidgetTemplate) {
+	*out = *in
+}
+
+func (in *WidgetClass) DeepCopyInto(out *WidgetClass) {
+	*out = *in
+}
@@ -0,0 +1,12 @@
+package v1alpha1
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/c/meta"
+)
+
+// Sprocket is declared in a package without a group.
+type Sprocket struct {
+	meta.TypeMeta
+	meta.ObjectMeta
+	Teeth int
+}
@@ -0,0 +1,19 @@
+package v1beta1
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/c/meta"
+)
+
+// GroupVersion mirrors schema.GroupVersion.
+type GroupVersion struct {
+	Group   string
+	Version string
+}
+
+var SchemeGroupVersion = GroupVersion{Group: "sprockets.example.com", Version: "v1beta1"}
+
+type Sprocket struct {
+	meta.TypeMeta
+	meta.ObjectMeta
+	Teeth int
+}
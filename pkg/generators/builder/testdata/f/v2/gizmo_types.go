@@ -0,0 +1,14 @@
+package v2
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/c/meta"
+)
+
+// +kubebuilder:object:root=true
+
+// Gizmo is declared in a kubebuilder package.
+type Gizmo struct {
+	meta.TypeMeta
+	meta.ObjectMeta
+	Teeth int
+}
@@ -0,0 +1,13 @@
+// Package v2 contains API Schema definitions laid out by kubebuilder.
+// +kubebuilder:object:generate=true
+// +groupName=gizmos.example.com
+package v2
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: "gizmos.example.com", Version: "v2"}
+)
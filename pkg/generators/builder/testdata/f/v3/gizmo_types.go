@@ -0,0 +1,12 @@
+package v3
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/c/meta"
+)
+
+// Gizmo is declared in a package with a GroupVersion but without a +groupName tag.
+type Gizmo struct {
+	meta.TypeMeta
+	meta.ObjectMeta
+	Teeth int
+}
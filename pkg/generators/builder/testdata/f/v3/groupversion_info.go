@@ -0,0 +1,7 @@
+package v3
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var GroupVersion = schema.GroupVersion{Group: "gadgets.example.com", Version: "v3"}
@@ -0,0 +1,2 @@
+// +kanopy:builder=package,style=options
+package g
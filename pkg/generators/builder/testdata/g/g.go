@@ -0,0 +1,19 @@
+package g
+
+import (
+	v1 "github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/f/v1"
+)
+
+type Widget struct {
+	v1.Widget
+}
+
+// +kanopy:builder=true,required=auto
+type Port struct {
+	v1.Port
+}
+
+// +kanopy:builder=true,style=builder
+type WidgetTemplate struct {
+	v1.WidgetTemplate
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/c/meta"
+)
+
+type Cluster struct {
+	meta.TypeMeta
+	meta.ObjectMeta
+	Spec ClusterSpec `json:"spec"`
+	// Read-only.
+	Status ClusterStatus `json:"status"`
+}
+
+type ClusterSpec struct {
+	Replicas int32           `json:"replicas"`
+	Nodes    []Node          `json:"nodes,omitempty"`
+	Template *NodeTemplate   `json:"template,omitempty"`
+	Metadata meta.ObjectMeta `json:"metadata"`
+	Ignored  string          `json:"-"`
+}
+
+type Node struct {
+	Name string `json:"name"`
+}
+
+type NodeTemplate struct {
+	Image string `json:"image"`
+}
+
+type ClusterStatus struct {
+	Ready bool `json:"ready"`
+}
+
+type Shard struct {
+	Config ShardConfig `json:"config"`
+}
+
+type ShardConfig struct {
+	Size int `json:"size"`
+}
@@ -0,0 +1,21 @@
+package h
+
+import (
+	v1 "github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/f/v1"
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/h/api"
+)
+
+// +kanopy:builder=true,applyconfig=true,deep=true,getters=true,gvk=example.com/v1/Cluster
+type Cluster struct {
+	api.Cluster
+}
+
+// +kanopy:builder=true,applyconfig=true
+type Port struct {
+	v1.Port
+}
+
+// +kanopy:builder=true,applyconfig=true
+type Shard struct {
+	api.Shard
+}
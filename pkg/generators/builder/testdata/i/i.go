@@ -0,0 +1,21 @@
+package i
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kanopy:builder=true
+type Deployment struct {
+	appsv1.Deployment
+}
+
+// +kanopy:builder=true,immutable=true
+type Container struct {
+	corev1.Container
+}
+
+// +kanopy:builder=true,render=true
+type Service struct {
+	corev1.Service
+}
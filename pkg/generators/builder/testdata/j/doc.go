@@ -0,0 +1,3 @@
+package j
+
+// +kanopy:builder=package
@@ -0,0 +1,15 @@
+package j
+
+import (
+	eapi "github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/e/api"
+)
+
+// +kanopy:builder=true
+type NodeConfig struct {
+	eapi.NodeConfig
+}
+
+// +kanopy:builder=true
+type ClusterNode struct {
+	eapi.Node
+}
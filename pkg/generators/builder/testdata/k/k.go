@@ -0,0 +1,11 @@
+package k
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The wrapper synthesized for corev1.PodDNSConfigOption would collide with the option type of PodDNSConfig.
+// +kanopy:builder=true,deep=true,style=options
+type PodSpec struct {
+	corev1.PodSpec
+}
@@ -0,0 +1,15 @@
+package renamed
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kanopy:builder=true,deep=true,style=options
+type PodSpec struct {
+	corev1.PodSpec
+}
+
+// +kanopy:builder=true,style=options
+type DNSConfigOption struct {
+	corev1.PodDNSConfigOption
+}
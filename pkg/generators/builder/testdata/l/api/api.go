@@ -0,0 +1,17 @@
+package api
+
+type Pool struct {
+	Nodes       []*Node         `json:"nodes,omitempty"`
+	NodesByZone map[string]Node `json:"nodesByZone,omitempty"`
+}
+
+type Node struct {
+	// +kubebuilder:validation:MinLength=3
+	Name  string `json:"name"`
+	Disks []Disk `json:"disks,omitempty"`
+}
+
+type Disk struct {
+	// +kubebuilder:validation:Minimum=1
+	Size int `json:"size"`
+}
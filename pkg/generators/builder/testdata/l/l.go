@@ -0,0 +1,22 @@
+package l
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/l/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kanopy:builder=true
+type MyDeployment struct {
+	appsv1.Deployment
+}
+
+// +kanopy:builder=true,immutable=true
+type MyContainer struct {
+	corev1.Container
+}
+
+// +kanopy:builder=true
+type MyPool struct {
+	api.Pool
+}
@@ -0,0 +1,10 @@
+package m
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// +kanopy:builder=true,deep=true,applyconfig=true
+type Deployment struct {
+	appsv1.Deployment
+}
@@ -0,0 +1,2 @@
+// +kanopy:builder=package,getters=true,immutable=true
+package n
@@ -0,0 +1,14 @@
+package n
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kanopy:builder=true,getters=false,immutable=false
+type Container struct {
+	corev1.Container
+}
+
+type ContainerPort struct {
+	corev1.ContainerPort
+}
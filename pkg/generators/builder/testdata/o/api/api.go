@@ -0,0 +1,18 @@
+package api
+
+// Schedule implements DeepCopyInto, its Window does not.
+type Schedule struct {
+	Name   string  `json:"name"`
+	Window *Window `json:"window,omitempty"`
+}
+
+func (in *Schedule) DeepCopyInto(out *Schedule) {
+	*out = *in
+	if in.Window != nil {
+		out.Window = &Window{Start: in.Window.Start}
+	}
+}
+
+type Window struct {
+	Start string `json:"start"`
+}
@@ -0,0 +1,16 @@
+package o
+
+import (
+	"github.com/kanopy-platform/code-generator/pkg/generators/builder/testdata/o/api"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// +kanopy:builder=true,deep=true,immutable=true
+type CronJob struct {
+	batchv1.CronJob
+}
+
+// +kanopy:builder=true,deep=true,immutable=true
+type Schedule struct {
+	api.Schedule
+}
@@ -0,0 +1,79 @@
+package builder
+
+import (
+	"fmt"
+	"go/token"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/kanopy-platform/code-generator/pkg/generators/index"
+	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+const (
+	TypeMeta = "TypeMeta"
+)
+
+var apiVersionPattern = regexp.MustCompile(`^v[1-9][0-9]*((alpha|beta)[1-9][0-9]*)?$`)
+
+// typeMeta returns the APIVersion and Kind set by the constructor of the root type, both are empty if they are unknown.
+// The gvk=<group>/<version>/<Kind> argument takes precedence over the group and version path of the upstream package.
+func typeMeta(t *types.Type) (string, string, error) {
+	parent := getParentOfEmbeddedType(t, TypeMeta)
+
+	if gvk := tags.ExtractGVK(t); gvk != "" {
+		if parent == nil {
+			return "", "", fmt.Errorf("type %s: %s=%s requires an embedded %s", t.Name, tags.GVKFlag, gvk, TypeMeta)
+		}
+		apiVersion, kind, err := parseGVK(gvk)
+		if err != nil {
+			return "", "", fmt.Errorf("type %s: %w", t.Name, err)
+		}
+		return apiVersion, kind, nil
+	}
+
+	if parent == nil {
+		return "", "", nil
+	}
+
+	version := path.Base(parent.Name.Package)
+	if !apiVersionPattern.MatchString(version) {
+		return "", "", nil
+	}
+
+	group, ok, err := index.GroupName(parent.Name.Package)
+	if err != nil {
+		return "", "", fmt.Errorf("type %s: %w", t.Name, err)
+	}
+	if !ok {
+		log.Warnf("Type: %s has no TypeMeta defaults, package %s has no +groupName tag, GroupName constant, SchemeGroupVersion or GroupVersion", t.Name, parent.Name.Package)
+		return "", "", nil
+	}
+
+	return apiVersion(group, version), parent.Name.Name, nil
+}
+
+// parseGVK parses <group>/<version>/<Kind>, the group of the core API is omitted, e.g. v1/Pod.
+func parseGVK(gvk string) (string, string, error) {
+	parts := strings.Split(gvk, "/")
+	if len(parts) == 2 {
+		parts = append([]string{""}, parts...)
+	}
+
+	if len(parts) != 3 || !apiVersionPattern.MatchString(parts[1]) || !token.IsIdentifier(parts[2]) || namer.IsPrivateGoName(parts[2]) {
+		return "", "", fmt.Errorf("%s=%s must be of the form <group>/<version>/<Kind>, e.g. apps/v1/Deployment", tags.GVKFlag, gvk)
+	}
+
+	return apiVersion(parts[0], parts[1]), parts[2], nil
+}
+
+func apiVersion(group, version string) string {
+	if group == "" {
+		return version
+	}
+	return group + "/" + version
+}
@@ -0,0 +1,38 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGVK(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		gvk            string
+		wantAPIVersion string
+		wantKind       string
+		wantErr        bool
+	}{
+		{gvk: "apps/v1/Deployment", wantAPIVersion: "apps/v1", wantKind: "Deployment"},
+		{gvk: "networking.k8s.io/v1beta1/Ingress", wantAPIVersion: "networking.k8s.io/v1beta1", wantKind: "Ingress"},
+		{gvk: "v1/Pod", wantAPIVersion: "v1", wantKind: "Pod"},
+		{gvk: "/v1/Pod", wantAPIVersion: "v1", wantKind: "Pod"},
+		{gvk: "Pod", wantErr: true},
+		{gvk: "apps/latest/Deployment", wantErr: true},
+		{gvk: "apps/v1/deployment", wantErr: true},
+		{gvk: "a/b/v1/Deployment", wantErr: true},
+	}
+
+	for _, test := range tests {
+		apiVersion, kind, err := parseGVK(test.gvk)
+		if test.wantErr {
+			assert.Error(t, err, test.gvk)
+			continue
+		}
+		assert.NoError(t, err, test.gvk)
+		assert.Equal(t, test.wantAPIVersion, apiVersion, test.gvk)
+		assert.Equal(t, test.wantKind, kind, test.gvk)
+	}
+}
@@ -15,13 +15,15 @@ type BuilderFactory interface {
 }
 
 type PackageTypeIndex struct {
-	TypesByTypePath map[string]*types.Type
-	PackageRoot     string
+	TypesByTypePath         map[string]*types.Type
+	SyntheticTypesByPackage map[string][]*types.Type
+	PackageRoot             string
 }
 
 func NewPackageTypeIndex() *PackageTypeIndex {
 	return &PackageTypeIndex{
-		TypesByTypePath: map[string]*types.Type{},
+		TypesByTypePath:         map[string]*types.Type{},
+		SyntheticTypesByPackage: map[string][]*types.Type{},
 	}
 }
 
@@ -78,6 +80,11 @@ func (g *Generators) Packages(context *generator.Context, arguments *args.Genera
 		}
 	}
 
+	// deep wrappers are indexed once every explicitly tagged type is known so explicit wrappers take precedence
+	for _, pkg := range packages {
+		buildDeepPackageIndex(g.Index, pkg)
+	}
+
 	gp := generator.Packages{}
 	for _, pkg := range packages {
 		if tags.IsPackageTagged(pkg.Comments) || doPackageTypesNeedGeneration(pkg) {
@@ -112,6 +119,14 @@ func buildPackageIndex(packageIndex *PackageTypeIndex, pkg *types.Package) {
 	packageIndex.TypesByTypePath = index.BuildPackageIndex(packageIndex.TypesByTypePath, pkg)
 }
 
+func buildDeepPackageIndex(packageIndex *PackageTypeIndex, pkg *types.Package) {
+	var synthetic []*types.Type
+	packageIndex.TypesByTypePath, synthetic = index.BuildDeepPackageIndex(packageIndex.TypesByTypePath, pkg)
+	if len(synthetic) > 0 {
+		packageIndex.SyntheticTypesByPackage[pkg.Path] = synthetic
+	}
+}
+
 func doPackageTypesNeedGeneration(pkg *types.Package) bool {
 	for _, t := range pkg.Types {
 		if tags.IsTypeEnabled(t) {
@@ -0,0 +1,242 @@
+package index
+
+import (
+	"fmt"
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/parser"
+	"k8s.io/gengo/types"
+)
+
+// loadedPackages caches packages loaded outside of the generator universe by import path.
+var loadedPackages = map[string]*types.Package{}
+
+// LoadPackage parses the package at the import path. Packages are only parsed once.
+func LoadPackage(pkgPath string) (*types.Package, error) {
+	if pkg, ok := loadedPackages[pkgPath]; ok {
+		return pkg, nil
+	}
+
+	log.Debugf("Loading package %s", pkgPath)
+	b := parser.New()
+	if err := b.AddDir(pkgPath); err != nil {
+		return nil, fmt.Errorf("loading package %s: %w", pkgPath, err)
+	}
+
+	u, err := b.FindTypes()
+	if err != nil {
+		return nil, fmt.Errorf("loading package %s: %w", pkgPath, err)
+	}
+
+	pkg, ok := u[pkgPath]
+	if !ok {
+		return nil, fmt.Errorf("package %s not found", pkgPath)
+	}
+
+	loadedPackages[pkgPath] = pkg
+	return pkg, nil
+}
+
+// LoadType returns the named type as declared in its package, including its comment lines,
+// which are not parsed for types of packages outside of the generator inputs.
+func LoadType(name types.Name) (*types.Type, error) {
+	pkg, err := LoadPackage(name.Package)
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := pkg.Types[name.Name]
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", name)
+	}
+	return t, nil
+}
+
+// HasMethod returns true if the type declares the method. The declaring package is loaded again without the
+// generator build tag, which hides generated files such as zz_generated.deepcopy.go from the generator universe.
+func HasMethod(t *types.Type, method string) bool {
+	if _, ok := t.Methods[method]; ok {
+		return true
+	}
+
+	loaded, err := LoadType(t.Name)
+	if err != nil {
+		log.Debugf("Looking up method %s of %s: %v", method, t.Name, err)
+		return false
+	}
+	_, ok := loaded.Methods[method]
+	return ok
+}
+
+// Constant is an exported constant of an upstream package.
+type Constant struct {
+	Name  string
+	Value string
+}
+
+// EnumConstants returns every exported constant declared with the referenced type,
+// e.g. k8s.io/api/core/v1.PullPolicy, ordered by constant name.
+func EnumConstants(ref string) ([]Constant, error) {
+	i := strings.LastIndex(ref, ".")
+	if i < 0 || i < strings.LastIndex(ref, "/") {
+		return nil, fmt.Errorf("ref %q must be of the form <import path>.<Type>", ref)
+	}
+
+	pkg, err := LoadPackage(ref[:i])
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name, c := range pkg.Constants {
+		if namer.IsPrivateGoName(name) || c.Underlying == nil || c.Underlying.Name.String() != ref || c.ConstValue == nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no exported constants of type %s found", ref)
+	}
+
+	constants := []Constant{}
+	for _, name := range names {
+		constants = append(constants, Constant{Name: name, Value: *pkg.Constants[name].ConstValue})
+	}
+	return constants, nil
+}
+
+// GroupName returns the API group of the package at the import path from its +groupName tag, or else from
+// its GroupName constant or the Group of its SchemeGroupVersion or GroupVersion variable. k8s.io/api packages tag doc.go
+// and declare the constant and SchemeGroupVersion in register.go, kubebuilder packages tag the package clause of
+// groupversion_info.go and declare GroupVersion there.
+// The group of the core API is empty, so the second result reports whether the group was found.
+func GroupName(pkgPath string) (string, bool, error) {
+	pkg, err := LoadPackage(pkgPath)
+	if err != nil {
+		return "", false, err
+	}
+
+	if group, ok := tags.ExtractGroupName(pkg); ok {
+		return group, true, nil
+	}
+
+	files, err := parseFiles(pkg)
+	if err != nil {
+		return "", false, err
+	}
+
+	// gengo only reads the package comments of doc.go
+	for _, f := range files {
+		if f.Doc == nil {
+			continue
+		}
+		if group, ok := tags.ExtractGroupNameFromComments(strings.Split(f.Doc.Text(), "\n")); ok {
+			return group, true, nil
+		}
+	}
+
+	if c, ok := pkg.Constants[groupNameConstant]; ok && c.ConstValue != nil {
+		return *c.ConstValue, true, nil
+	}
+
+	for _, name := range groupVersionVariables {
+		if _, ok := pkg.Variables[name]; !ok {
+			continue
+		}
+		for _, f := range files {
+			for _, decl := range f.Decls {
+				if group, ok := groupOfDecl(pkg, decl, name); ok {
+					return group, true, nil
+				}
+			}
+		}
+	}
+	return "", false, nil
+}
+
+const groupNameConstant = "GroupName"
+
+// groupVersionVariables are the schema.GroupVersion variables declaring the group, in order of precedence.
+var groupVersionVariables = []string{"SchemeGroupVersion", "GroupVersion"}
+
+// parseFiles parses the non-test files of the package with their comments.
+func parseFiles(pkg *types.Package) ([]*ast.File, error) {
+	if pkg.SourcePath == "" {
+		return nil, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(pkg.SourcePath, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	files := []*ast.File{}
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		f, err := goparser.ParseFile(fset, path, nil, goparser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// groupOfDecl returns the Group of the named GroupVersion composite literal declared by decl,
+// either a string literal or a constant of the package.
+func groupOfDecl(pkg *types.Package, decl ast.Decl, variable string) (string, bool) {
+	gen, ok := decl.(*ast.GenDecl)
+	if !ok || gen.Tok != token.VAR {
+		return "", false
+	}
+
+	for _, spec := range gen.Specs {
+		value := spec.(*ast.ValueSpec)
+		for i, name := range value.Names {
+			if name.Name != variable || i >= len(value.Values) {
+				continue
+			}
+			lit, ok := value.Values[i].(*ast.CompositeLit)
+			if !ok {
+				return "", false
+			}
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					return "", false
+				}
+				if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "Group" {
+					continue
+				}
+				switch group := kv.Value.(type) {
+				case *ast.BasicLit:
+					if unquoted, err := strconv.Unquote(group.Value); err == nil {
+						return unquoted, true
+					}
+				case *ast.Ident:
+					if c, ok := pkg.Constants[group.Name]; ok && c.ConstValue != nil {
+						return *c.ConstValue, true
+					}
+				}
+				return "", false
+			}
+			// Group is omitted for the core API
+			return "", true
+		}
+	}
+	return "", false
+}
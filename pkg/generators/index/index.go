@@ -1,6 +1,12 @@
 package index
 
 import (
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/namer"
 	"k8s.io/gengo/types"
 
 	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
@@ -29,3 +35,208 @@ func BuildPackageIndex(index map[string]*types.Type, pkg *types.Package) map[str
 	}
 	return index
 }
+
+// deepExcludedTypeNames are the upstream types handled by dedicated builder logic rather than a wrapper.
+var deepExcludedTypeNames = map[string]bool{
+	"ObjectMeta": true,
+	"TypeMeta":   true,
+	"ListMeta":   true,
+}
+
+// inheritedArgs are the arguments of a deep root applied to the wrappers it synthesizes.
+// Arguments describing the root itself, e.g. gvk, required or namespaced, are not inherited.
+var inheritedArgs = []string{tags.GettersFlag, tags.ImmutableFlag, tags.StyleFlag, tags.ApplyConfig}
+
+// BuildDeepPackageIndex synthesizes a wrapper type for every struct type transitively reachable from
+// the parent of a type tagged with the deep argument. Types already present in the index keep their
+// wrapper. The synthesized types belong to pkg and are returned in a deterministic order.
+func BuildDeepPackageIndex(index map[string]*types.Type, pkg *types.Package) (map[string]*types.Type, []*types.Type) {
+	names := map[string]bool{}
+	for name := range pkg.Types {
+		names[name] = true
+	}
+
+	roots := []string{}
+	for name, t := range pkg.Types {
+		if tags.IsTypeEnabled(t) && tags.IsTypeArgEnabled(t, tags.DeepFlag) {
+			roots = append(roots, name)
+		}
+	}
+	sort.Strings(roots)
+
+	synthetic := []*types.Type{}
+	visited := map[*types.Type]bool{}
+	for _, name := range roots {
+		root := pkg.Types[name]
+		for _, m := range root.Members {
+			if !m.Embedded || m.Type.Kind != types.Struct {
+				continue
+			}
+
+			reachables := collectReachableStructs(m.Type, visited)
+			reserved := map[string]bool{}
+			if isOptionsStyle(pkg, root) {
+				// the option types of the wrappers, e.g. PodDNSConfigOption, are declared in the package too
+				for name := range names {
+					reserved[name+"Option"] = true
+				}
+				for _, reachable := range reachables {
+					reserved[reachable.Name.Name+"Option"] = true
+				}
+			}
+
+			for _, reachable := range reachables {
+				if _, ok := index[reachable.String()]; ok {
+					continue
+				}
+
+				wrapperName := syntheticTypeName(names, reserved, reachable)
+				if wrapperName == "" {
+					log.Warnf("Skipping deep wrapper for %s: type name already used in package %s", reachable, pkg.Path)
+					continue
+				}
+				names[wrapperName] = true
+
+				wrapper := &types.Type{
+					Name:         types.Name{Package: pkg.Path, Name: wrapperName},
+					Kind:         types.Struct,
+					CommentLines: inheritedComments(root, reachable),
+					Members:      []types.Member{{Name: reachable.Name.Name, Embedded: true, Type: reachable}},
+				}
+				index[reachable.String()] = wrapper
+				synthetic = append(synthetic, wrapper)
+				log.Debugf("Deep indexing %s -> %s (root %s)", reachable, wrapper.Name, root.Name)
+			}
+		}
+	}
+	return index, synthetic
+}
+
+// inheritedComments returns the builder tag of the wrapper of parent synthesized by root, holding the inherited arguments of root.
+// immutable is not inherited by the wrappers of types without a DeepCopyInto method, which could not be copied on write.
+func inheritedComments(root *types.Type, parent *types.Type) []string {
+	args := []string{tags.BuilderOptIn}
+	for _, arg := range inheritedArgs {
+		if arg == tags.ImmutableFlag && !HasMethod(parent, "DeepCopyInto") {
+			continue
+		}
+		switch v := tags.ExtractTypeArg(root, arg); v {
+		case "":
+		case arg:
+			args = append(args, arg)
+		default:
+			args = append(args, arg+"="+v)
+		}
+	}
+	return []string{"+" + tags.Builder + "=" + strings.Join(args, ",")}
+}
+
+// collectReachableStructs walks the members of t depth first and returns every eligible struct type
+// reachable through struct, pointer, slice and map members. Visited types are never walked twice,
+// which breaks cycles between recursive types.
+func collectReachableStructs(t *types.Type, visited map[*types.Type]bool) []*types.Type {
+	out := []*types.Type{}
+	for _, m := range InlineMembers(t) {
+		if m.Embedded || namer.IsPrivateGoName(m.Name) || tags.IsMemberSkipped(m) {
+			continue
+		}
+
+		s := memberStruct(m.Type)
+		if s == nil || visited[s] || !isDeepCandidate(s) {
+			continue
+		}
+		visited[s] = true
+
+		out = append(out, s)
+		out = append(out, collectReachableStructs(s, visited)...)
+	}
+	return out
+}
+
+// InlineMembers returns the members of t, replacing the structs embedded with json:",inline" by their own members,
+// e.g. the handler of a probe. The promoted members are serialized and accessed as members of t.
+// TypeMeta and the other types handled by dedicated builder logic are kept as embedded members.
+func InlineMembers(t *types.Type) []types.Member {
+	out := []types.Member{}
+	for _, m := range t.Members {
+		if !isInline(m) {
+			out = append(out, m)
+			continue
+		}
+		out = append(out, InlineMembers(m.Type)...)
+	}
+	return out
+}
+
+func isInline(m types.Member) bool {
+	if !m.Embedded || m.Type.Kind != types.Struct || deepExcludedTypeNames[m.Type.Name.Name] {
+		return false
+	}
+	options := strings.Split(reflect.StructTag(m.Tags).Get("json"), ",")
+	for _, option := range options[1:] {
+		if option == "inline" {
+			return options[0] == ""
+		}
+	}
+	return false
+}
+
+// memberStruct returns the named struct type held by a member, unwrapping a single slice or map and a pointer.
+func memberStruct(t *types.Type) *types.Type {
+	if t.Kind == types.Slice || t.Kind == types.Map {
+		t = t.Elem
+	}
+	if t.Kind == types.Pointer {
+		t = t.Elem
+	}
+	if t.Kind != types.Struct || t.Name.Name == "" {
+		return nil
+	}
+	return t
+}
+
+// isDeepCandidate returns true if the struct has exported members set one by one, i.e. it does not marshal itself
+// like an IntOrString or a Quantity.
+func isDeepCandidate(t *types.Type) bool {
+	if deepExcludedTypeNames[t.Name.Name] || isStandardLibrary(t.Name.Package) {
+		return false
+	}
+	if HasMethod(t, "MarshalJSON") || HasMethod(t, "MarshalText") {
+		return false
+	}
+
+	for _, m := range InlineMembers(t) {
+		if !m.Embedded && !namer.IsPrivateGoName(m.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isStandardLibrary(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+// syntheticTypeName returns the upstream type name, prefixed with its package name when it is taken or reserved.
+// An empty string is returned when both names are unavailable.
+func syntheticTypeName(names map[string]bool, reserved map[string]bool, t *types.Type) string {
+	if !names[t.Name.Name] && !reserved[t.Name.Name] {
+		return t.Name.Name
+	}
+
+	prefixed := namer.IC(path.Base(t.Name.Package)) + t.Name.Name
+	if !names[prefixed] && !reserved[prefixed] {
+		return prefixed
+	}
+	return ""
+}
+
+// isOptionsStyle returns true if the root type, or else its package, selects the functional options style,
+// which the wrappers synthesized by the root inherit.
+func isOptionsStyle(pkg *types.Package, root *types.Type) bool {
+	style := tags.ExtractTypeStyle(root)
+	if style == "" {
+		style = tags.ExtractStyle(pkg.Comments)
+	}
+	return style == tags.StyleOptions
+}
@@ -0,0 +1,116 @@
+package members
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/gengo/types"
+)
+
+// Config decides which members of a parent type get setters and getters.
+type Config struct {
+	// ReadOnly are case insensitive regular expressions, a member is read-only if one matches a line of its comment.
+	ReadOnly []string `yaml:"readOnly"`
+	// Types are member rules keyed by fully qualified type name, e.g. k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta.
+	// A bare type name applies to the type of every package.
+	Types map[string]Rules `yaml:"types"`
+
+	readOnly []*regexp.Regexp
+}
+
+// Rules list member names of a type.
+type Rules struct {
+	// Include members even if they are read-only.
+	Include []string `yaml:"include,omitempty"`
+	// Exclude members.
+	Exclude []string `yaml:"exclude,omitempty"`
+}
+
+// Default returns the default profile, which excludes read-only members and ObjectMeta.Finalizers.
+func Default() *Config {
+	c := &Config{
+		ReadOnly: []string{"read-only"},
+		Types: map[string]Rules{
+			"ObjectMeta": {Exclude: []string{"Finalizers"}},
+		},
+	}
+
+	if err := c.compile(); err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// LoadConfig reads a configuration file on top of the default profile.
+// Rules of a type replace the default rules of the same key and readOnly replaces the default patterns.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := Default()
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if err := c.compile(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+func (c *Config) compile() error {
+	c.readOnly = nil
+	for _, pattern := range c.ReadOnly {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return fmt.Errorf("readOnly pattern %q: %w", pattern, err)
+		}
+		c.readOnly = append(c.readOnly, re)
+	}
+	return nil
+}
+
+// IsReadOnly returns true if a line of the member comment matches a read-only pattern.
+func (c *Config) IsReadOnly(m types.Member) bool {
+	for _, line := range m.CommentLines {
+		for _, re := range c.readOnly {
+			if re.MatchString(line) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsExcluded returns true if the rules of the parent type exclude the member.
+func (c *Config) IsExcluded(parent *types.Type, m types.Member) bool {
+	return contains(c.rules(parent).Exclude, m.Name)
+}
+
+// IsIncluded returns true if the rules of the parent type include the member.
+func (c *Config) IsIncluded(parent *types.Type, m types.Member) bool {
+	return contains(c.rules(parent).Include, m.Name)
+}
+
+// rules prefers the fully qualified key over the bare type name.
+func (c *Config) rules(t *types.Type) Rules {
+	if r, ok := c.Types[t.Name.String()]; ok {
+		return r
+	}
+	return c.Types[t.Name.Name]
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
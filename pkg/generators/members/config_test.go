@@ -0,0 +1,68 @@
+package members
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/gengo/types"
+)
+
+func TestDefault(t *testing.T) {
+	t.Parallel()
+
+	c := Default()
+	objectMeta := &types.Type{Name: types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ObjectMeta"}}
+
+	assert.True(t, c.IsExcluded(objectMeta, types.Member{Name: "Finalizers"}))
+	assert.False(t, c.IsExcluded(objectMeta, types.Member{Name: "Labels"}))
+	assert.True(t, c.IsReadOnly(types.Member{CommentLines: []string{"UID is ...", "Read-only."}}))
+	assert.True(t, c.IsReadOnly(types.Member{CommentLines: []string{"not read-only after creation"}}))
+	assert.False(t, c.IsReadOnly(types.Member{CommentLines: []string{"Name is ..."}}))
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	c, err := LoadConfig("./testdata/members.yaml")
+	assert.NoError(t, err)
+
+	status := &types.Type{Name: types.Name{Package: "k8s.io/api/apps/v1", Name: "DeploymentStatus"}}
+	deployment := &types.Type{Name: types.Name{Package: "k8s.io/api/apps/v1", Name: "Deployment"}}
+	objectMeta := &types.Type{Name: types.Name{Package: "k8s.io/apimachinery/pkg/apis/meta/v1", Name: "ObjectMeta"}}
+	otherStatus := &types.Type{Name: types.Name{Package: "example.com/api/v1", Name: "DeploymentStatus"}}
+
+	assert.True(t, c.IsIncluded(status, types.Member{Name: "ObservedGeneration"}))
+	assert.False(t, c.IsIncluded(otherStatus, types.Member{Name: "ObservedGeneration"}))
+	assert.True(t, c.IsExcluded(deployment, types.Member{Name: "Status"}))
+	// default rules are kept
+	assert.True(t, c.IsExcluded(objectMeta, types.Member{Name: "Finalizers"}))
+	// readOnly replaces the default patterns
+	assert.True(t, c.IsReadOnly(types.Member{CommentLines: []string{"Read-only."}}))
+	assert.False(t, c.IsReadOnly(types.Member{CommentLines: []string{"not read-only after creation"}}))
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	invalidPattern := filepath.Join(dir, "pattern.yaml")
+	assert.NoError(t, os.WriteFile(invalidPattern, []byte("readOnly:\n  - \"(\"\n"), 0o600))
+	invalidYAML := filepath.Join(dir, "yaml.yaml")
+	assert.NoError(t, os.WriteFile(invalidYAML, []byte("types: [\n"), 0o600))
+
+	tests := []struct {
+		description string
+		path        string
+	}{
+		{description: "missing file", path: filepath.Join(dir, "missing.yaml")},
+		{description: "invalid pattern", path: invalidPattern},
+		{description: "invalid yaml", path: invalidYAML},
+	}
+
+	for _, test := range tests {
+		_, err := LoadConfig(test.path)
+		assert.Error(t, err, test.description)
+	}
+}
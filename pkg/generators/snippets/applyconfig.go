@@ -0,0 +1,221 @@
+package snippets
+
+import (
+	"fmt"
+	"strconv"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+const runtimePackage = "k8s.io/apimachinery/pkg/runtime"
+
+// ObjectMetaApplyConfiguration is the name of the apply configuration of ObjectMeta, generated once per package.
+const ObjectMetaApplyConfiguration = "ObjectMetaApplyConfiguration"
+
+// ApplyField is a member of the parent type in an apply configuration.
+type ApplyField struct {
+	Member types.Member
+	// JSON is the serialized name of the member.
+	JSON string
+	// Apply is the apply configuration of the member type, or of the element type of a slice member,
+	// empty if the field keeps the upstream type.
+	Apply string
+}
+
+// ApplyConfigurationName returns the name of the apply configuration of t.
+func ApplyConfigurationName(t *types.Type) string {
+	return t.Name.Name + "ApplyConfiguration"
+}
+
+// GenerateApplyConfiguration generates a struct of nil-able fields, so that only the fields that are set are serialized.
+// typeMeta and objectMeta are nil unless the parent type embeds them.
+func GenerateApplyConfiguration(t *types.Type, parent *types.Type, typeMeta, objectMeta *types.Type, fields []ApplyField) (string, generator.Args) {
+	args := applyArgs(t)
+	args["parent"] = parent
+	args["typeMeta"] = typeMeta
+	args["objectMeta"] = objectMeta
+
+	body := ""
+	if typeMeta != nil {
+		body += "\t$.typeMeta|raw$ `json:\",inline\"`\n"
+	}
+	if objectMeta != nil {
+		body += "\tObjectMeta *" + ObjectMetaApplyConfiguration + " `json:\"metadata,omitempty\"`\n"
+	}
+	body += applyFields(args, fields)
+
+	raw := `// $.apply$ is an autogenerated apply configuration of $.parent|raw$ for server-side apply.
+type $.apply$ struct {
+` + body + `}
+
+`
+	return named("ApplyConfiguration", raw, args)
+}
+
+// GenerateApplyObjectMeta generates the apply configuration of the ObjectMeta members set by clients, e.g. name, labels and owner references.
+func GenerateApplyObjectMeta(objectMeta *types.Type, fields []ApplyField) (string, generator.Args) {
+	args := generator.Args{
+		"objectMeta": objectMeta,
+		"apply":      ObjectMetaApplyConfiguration,
+	}
+
+	raw := `// $.apply$ is an autogenerated apply configuration of $.objectMeta|raw$ for server-side apply.
+type $.apply$ struct {
+` + applyFields(args, fields) + `}
+
+`
+	return named("ApplyObjectMeta", raw, args)
+}
+
+// GenerateApplyConfigurationConstructor generates a constructor of the apply configuration setting the name and, unless kind is empty, the TypeMeta.
+// Without ObjectMeta the constructor returns an empty apply configuration.
+func GenerateApplyConfigurationConstructor(t *types.Type, objectMeta *types.Type, apiVersion, kind string) (string, generator.Args) {
+	args := applyArgs(t)
+	args["objectMeta"] = objectMeta
+	args["apiVersion"] = strconv.Quote(apiVersion)
+	args["kind"] = strconv.Quote(kind)
+
+	params := ""
+	body := ""
+	if objectMeta != nil {
+		params = "name string"
+		body = "\to.ObjectMeta = &" + ObjectMetaApplyConfiguration + "{Name: &name}\n"
+	}
+	if kind != "" {
+		body += `	o.APIVersion = $.apiVersion$
+	o.Kind = $.kind$
+`
+	}
+
+	raw := `// New$.apply$ is an autogenerated constructor.
+func New$.apply$(` + params + `) *$.apply$ {
+	o := &$.apply${}
+` + body + `	return o
+}
+
+`
+	return named("ApplyConfigurationConstructor", raw, args)
+}
+
+// GenerateApplySetter generates a function setting a field of the apply configuration, slices are appended and maps merged.
+func GenerateApplySetter(t *types.Type, field ApplyField) (string, generator.Args) {
+	args := applyArgs(t)
+	args["funcName"] = funcName(field.Member)
+	args["memberAccessor"] = field.Member.Name
+	args["memberType"] = field.Member.Type
+	args["applyType"] = field.Apply
+
+	raw := applyFunction(field.Member, field.Apply != "", false)
+	return named("ApplySetter", raw, args)
+}
+
+// GenerateApplyObjectMetaSetter generates a function setting a member of the ObjectMeta of the apply configuration.
+func GenerateApplyObjectMetaSetter(t *types.Type, objectMeta *types.Type, member types.Member) (string, generator.Args) {
+	args := applyArgs(t)
+	args["funcName"] = funcName(member)
+	args["memberAccessor"] = "ObjectMeta." + member.Name
+	args["memberType"] = member.Type
+	args["objectMeta"] = objectMeta
+
+	raw := applyFunction(member, false, true)
+	return named("ApplyObjectMetaSetter", raw, args)
+}
+
+// GenerateApplyToUnstructured generates a conversion of the apply configuration to the unstructured content of a server-side apply patch.
+func GenerateApplyToUnstructured(t *types.Type) (string, generator.Args) {
+	args := applyArgs(t)
+	args["converter"] = types.Ref(runtimePackage, "DefaultUnstructuredConverter")
+
+	raw := `// ToUnstructured is an autogenerated function
+func (o *$.apply$) ToUnstructured() (map[string]interface{}, error) {
+	return $.converter|raw$.ToUnstructured(o)
+}
+
+`
+	return named("ApplyToUnstructured", raw, args)
+}
+
+func applyArgs(t *types.Type) generator.Args {
+	args := defaultGeneratorArgs(t, true)
+	args["apply"] = ApplyConfigurationName(t)
+	return args
+}
+
+// applyFields returns the struct fields of the apply configuration and adds their member types to args.
+func applyFields(args generator.Args, fields []ApplyField) string {
+	body := ""
+	for i, f := range fields {
+		memberType := fmt.Sprintf("memberType%d", i)
+		args[memberType] = f.Member.Type
+		body += fmt.Sprintf("\t%s %s `json:%s`\n", f.Member.Name, applyFieldType(f, memberType), strconv.Quote(f.JSON+",omitempty"))
+	}
+	return body
+}
+
+// applyFieldType keeps pointers, slices and maps, which are already nil-able, and points to any other type.
+func applyFieldType(f ApplyField, memberType string) string {
+	if f.Apply != "" && f.Member.Type.Kind == types.Slice {
+		return "[]" + f.Apply
+	}
+	if f.Apply != "" {
+		return "*" + f.Apply
+	}
+	switch f.Member.Type.Kind {
+	case types.Pointer, types.Slice, types.Map:
+		return fmt.Sprintf("$.%s|raw$", memberType)
+	default:
+		return fmt.Sprintf("*$.%s|raw$", memberType)
+	}
+}
+
+// applyFunction returns a setter of the member, taking the apply configuration of the member type if apply is set.
+// With objectMeta, the member belongs to the ObjectMeta apply configuration, which is initialized first.
+func applyFunction(member types.Member, apply bool, objectMeta bool) string {
+	var params, body string
+	switch {
+	case apply && member.Type.Kind == types.Slice:
+		params = "in ...*$.applyType$"
+		body = `	for _, v := range in {
+		if v != nil {
+			o.$.memberAccessor$ = append(o.$.memberAccessor$, *v)
+		}
+	}
+`
+	case apply:
+		params = "in *$.applyType$"
+		body = "\to.$.memberAccessor$ = in\n"
+	case member.Type.Kind == types.Pointer:
+		params = "in $.memberType.Elem|raw$"
+		body = "\to.$.memberAccessor$ = &in\n"
+	case member.Type.Kind == types.Slice:
+		params = "in ...$.memberType.Elem|raw$"
+		body = "\to.$.memberAccessor$ = append(o.$.memberAccessor$, in...)\n"
+	case member.Type.Kind == types.Map:
+		params = "in $.memberType|raw$"
+		body = `	if o.$.memberAccessor$ == nil {
+		o.$.memberAccessor$ = make($.memberType|raw$, len(in))
+	}
+	for key, value := range in {
+		o.$.memberAccessor$[key] = value
+	}
+`
+	default:
+		params = "in $.memberType|raw$"
+		body = "\to.$.memberAccessor$ = &in\n"
+	}
+
+	if objectMeta {
+		body = `	if o.ObjectMeta == nil {
+		o.ObjectMeta = &` + ObjectMetaApplyConfiguration + `{}
+	}
+` + body
+	}
+
+	return `// $.funcName$ is an autogenerated function
+func (o *$.apply$) $.funcName$(` + params + `) *$.apply$ {
+` + body + `	return o
+}
+
+`
+}
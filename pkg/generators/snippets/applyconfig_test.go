@@ -0,0 +1,169 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+)
+
+func TestGenerateApplyConfiguration(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+	typeMeta := getMemberFromType(t, someStruct, "SomeStruct", "TypeMeta").Type
+	objectMeta := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta").Type
+	fields := []ApplyField{
+		{Member: getMemberFromType(t, someStruct, "SomeStruct", "AStruct"), JSON: "aStruct", Apply: "AStructApplyConfiguration"},
+		{Member: getMemberFromType(t, someStruct, "SomeStruct", "CStructs"), JSON: "cStructs", Apply: "CStructApplyConfiguration"},
+		{Member: getMemberFromType(t, someStruct, "SomeStruct", "Bool"), JSON: "bool"},
+		{Member: getMemberFromType(t, someStruct, "SomeStruct", "IntPtr"), JSON: "intPtr"},
+		{Member: getMemberFromType(t, someStruct, "SomeStruct", "Strings"), JSON: "strings"},
+		{Member: getMemberFromType(t, someStruct, "SomeStruct", "MapIntString"), JSON: "mapIntString"},
+	}
+
+	want := `// SomeStructApplyConfiguration is an autogenerated apply configuration of a.SomeStruct for server-side apply.
+type SomeStructApplyConfiguration struct {
+	b.TypeMeta ` + "`json:\",inline\"`" + `
+	ObjectMeta *ObjectMetaApplyConfiguration ` + "`json:\"metadata,omitempty\"`" + `
+	AStruct *AStructApplyConfiguration ` + "`json:\"aStruct,omitempty\"`" + `
+	CStructs []CStructApplyConfiguration ` + "`json:\"cStructs,omitempty\"`" + `
+	Bool *bool ` + "`json:\"bool,omitempty\"`" + `
+	IntPtr *int ` + "`json:\"intPtr,omitempty\"`" + `
+	Strings []string ` + "`json:\"strings,omitempty\"`" + `
+	MapIntString map[int]string ` + "`json:\"mapIntString,omitempty\"`" + `
+}
+
+// NewSomeStructApplyConfiguration is an autogenerated constructor.
+func NewSomeStructApplyConfiguration(name string) *SomeStructApplyConfiguration {
+	o := &SomeStructApplyConfiguration{}
+	o.ObjectMeta = &ObjectMetaApplyConfiguration{Name: &name}
+	o.APIVersion = "apps/v1"
+	o.Kind = "Deployment"
+	return o
+}
+
+// WithAStruct is an autogenerated function
+func (o *SomeStructApplyConfiguration) WithAStruct(in *AStructApplyConfiguration) *SomeStructApplyConfiguration {
+	o.AStruct = in
+	return o
+}
+
+// AppendCStructs is an autogenerated function
+func (o *SomeStructApplyConfiguration) AppendCStructs(in ...*CStructApplyConfiguration) *SomeStructApplyConfiguration {
+	for _, v := range in {
+		if v != nil {
+			o.CStructs = append(o.CStructs, *v)
+		}
+	}
+	return o
+}
+
+// WithBool is an autogenerated function
+func (o *SomeStructApplyConfiguration) WithBool(in bool) *SomeStructApplyConfiguration {
+	o.Bool = &in
+	return o
+}
+
+// WithIntPtr is an autogenerated function
+func (o *SomeStructApplyConfiguration) WithIntPtr(in int) *SomeStructApplyConfiguration {
+	o.IntPtr = &in
+	return o
+}
+
+// AppendStrings is an autogenerated function
+func (o *SomeStructApplyConfiguration) AppendStrings(in ...string) *SomeStructApplyConfiguration {
+	o.Strings = append(o.Strings, in...)
+	return o
+}
+
+// WithMapIntString is an autogenerated function
+func (o *SomeStructApplyConfiguration) WithMapIntString(in map[int]string) *SomeStructApplyConfiguration {
+	if o.MapIntString == nil {
+		o.MapIntString = make(map[int]string, len(in))
+	}
+	for key, value := range in {
+		o.MapIntString[key] = value
+	}
+	return o
+}
+
+// ToUnstructured is an autogenerated function
+func (o *SomeStructApplyConfiguration) ToUnstructured() (map[string]interface{}, error) {
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+}
+
+`
+	var buf bytes.Buffer
+	sw := generator.NewSnippetWriter(&buf, ctx, "$", "$")
+	sw.Do(GenerateApplyConfiguration(someStruct, parent, typeMeta, objectMeta, fields))
+	sw.Do(GenerateApplyConfigurationConstructor(someStruct, objectMeta, "apps/v1", "Deployment"))
+	for _, f := range fields {
+		sw.Do(GenerateApplySetter(someStruct, f))
+	}
+	sw.Do(GenerateApplyToUnstructured(someStruct))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, buf.String())
+}
+
+func TestGenerateApplyObjectMeta(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	objectMeta := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta").Type
+	fields := []ApplyField{
+		{Member: getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta", "Name"), JSON: "name"},
+		{Member: getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta", "Labels"), JSON: "labels"},
+		{Member: getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta", "OwnerReferences"), JSON: "ownerReferences"},
+	}
+
+	want := `// ObjectMetaApplyConfiguration is an autogenerated apply configuration of b.ObjectMeta for server-side apply.
+type ObjectMetaApplyConfiguration struct {
+	Name *string ` + "`json:\"name,omitempty\"`" + `
+	Labels map[string]string ` + "`json:\"labels,omitempty\"`" + `
+	OwnerReferences []b.OwnerReference ` + "`json:\"ownerReferences,omitempty\"`" + `
+}
+
+`
+	var buf bytes.Buffer
+	sw := generator.NewSnippetWriter(&buf, ctx, "$", "$")
+	sw.Do(GenerateApplyObjectMeta(objectMeta, fields))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, buf.String())
+}
+
+func TestGenerateApplyObjectMetaSetter(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	objectMeta := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta", "Finalizers")
+
+	want := `// AppendFinalizers is an autogenerated function
+func (o *SomeStructApplyConfiguration) AppendFinalizers(in ...string) *SomeStructApplyConfiguration {
+	if o.ObjectMeta == nil {
+		o.ObjectMeta = &ObjectMetaApplyConfiguration{}
+	}
+	o.ObjectMeta.Finalizers = append(o.ObjectMeta.Finalizers, in...)
+	return o
+}
+
+`
+	var buf bytes.Buffer
+	sw := generator.NewSnippetWriter(&buf, ctx, "$", "$")
+	sw.Do(GenerateApplyObjectMetaSetter(someStruct, objectMeta, member))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, buf.String())
+}
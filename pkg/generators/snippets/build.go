@@ -0,0 +1,59 @@
+package snippets
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// GenerateBuild generates Build and MustBuild returning a deep copy of the parent when it implements DeepCopyInto,
+// or else a shallow copy sharing maps, slices and pointers with the builder.
+func GenerateBuild(t *types.Type, parent *types.Type, hasDeepCopy bool) (string, generator.Args) {
+	args := generator.Args{
+		"type":        t,
+		"parent":      parent,
+		"parentName":  parent.Name.Name,
+		"hasDeepCopy": hasDeepCopy,
+	}
+
+	copied := "a deep copy of the underlying $.parent|raw$."
+	if !hasDeepCopy {
+		copied = "a shallow copy of the underlying $.parent|raw$,\n// maps, slices and pointers are shared with o."
+	}
+
+	raw := `// Build is an autogenerated function that validates and returns ` + copied + `
+func (o *$.type|raw$) Build() (*$.parent|raw$, error) {
+	if o == nil {
+		return nil, fmt.Errorf("cannot build from a nil $.type|raw$")
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+`
+
+	if hasDeepCopy {
+		raw += `	out := new($.parent|raw$)
+	o.$.parentName$.DeepCopyInto(out)
+	return out, nil
+}
+
+`
+	} else {
+		raw += `	out := o.$.parentName$
+	return &out, nil
+}
+
+`
+	}
+
+	raw += `// MustBuild is an autogenerated function that calls Build and panics on error.
+func (o *$.type|raw$) MustBuild() *$.parent|raw$ {
+	out, err := o.Build()
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+`
+	return named("Build", raw, args)
+}
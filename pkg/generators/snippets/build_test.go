@@ -0,0 +1,94 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+func TestGenerateBuild(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	tests := []struct {
+		description string
+		typ         *types.Type
+		parent      []string
+		deepCopy    bool
+		want        string
+	}{
+		{
+			description: "parent without DeepCopyInto is copied by value",
+			typ:         newTestType(t, "SomeStruct"),
+			parent:      []string{"SomeStruct"},
+			want: `// Build is an autogenerated function that validates and returns a shallow copy of the underlying a.SomeStruct,
+// maps, slices and pointers are shared with o.
+func (o *SomeStruct) Build() (*a.SomeStruct, error) {
+	if o == nil {
+		return nil, fmt.Errorf("cannot build from a nil SomeStruct")
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	out := o.SomeStruct
+	return &out, nil
+}
+
+// MustBuild is an autogenerated function that calls Build and panics on error.
+func (o *SomeStruct) MustBuild() *a.SomeStruct {
+	out, err := o.Build()
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+`,
+		},
+		{
+			description: "parent with DeepCopyInto is deep copied",
+			typ:         newTestType(t, "CStruct"),
+			parent:      []string{"CStruct"},
+			deepCopy:    true,
+			want: `// Build is an autogenerated function that validates and returns a deep copy of the underlying a.CStruct.
+func (o *CStruct) Build() (*a.CStruct, error) {
+	if o == nil {
+		return nil, fmt.Errorf("cannot build from a nil CStruct")
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	out := new(a.CStruct)
+	o.CStruct.DeepCopyInto(out)
+	return out, nil
+}
+
+// MustBuild is an autogenerated function that calls Build and panics on error.
+func (o *CStruct) MustBuild() *a.CStruct {
+	out, err := o.Build()
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+`,
+		},
+	}
+
+	for _, test := range tests {
+		parent := getMemberFromType(t, test.typ, test.parent...).Type
+
+		var b bytes.Buffer
+		sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+		sw.Do(GenerateBuild(test.typ, parent, test.deepCopy))
+		assert.NoError(t, sw.Error(), test.description)
+		assert.Equal(t, test.want, b.String(), test.description)
+	}
+}
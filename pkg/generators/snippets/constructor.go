@@ -1,35 +1,161 @@
 package snippets
 
 import (
+	"fmt"
+	"go/token"
+	gotypes "go/types"
+	"strconv"
+	"strings"
+	"unicode"
+
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/types"
 )
 
-func GenerateEmptyConstructor(t *types.Type, pointerReceiver bool) (string, generator.Args) {
-	args := defaultGeneratorArgs(t, pointerReceiver)
+// GenerateOptionType generates the functional option type of the constructors of t.
+func GenerateOptionType(t *types.Type) (string, generator.Args) {
+	args := defaultGeneratorArgs(t, true)
 
-	raw := `// New$.type|raw$ is an autogenerated constructor.
-func New$.type|raw$() $.pointer$$.type|raw$ {
-	o := $.ampersand$$.type|raw${}
-	return o
+	raw := `// $.type|raw$Option is an autogenerated functional option of New$.type|raw$.
+type $.type|raw$Option func(*$.type|raw$)
+
+`
+	return named("OptionType", raw, args)
+}
+
+func GenerateEmptyConstructor(t *types.Type, pointerReceiver bool, options bool) (string, generator.Args) {
+	args := defaultGeneratorArgs(t, pointerReceiver || options)
+
+	raw := constructor("New$.type|raw$", nil, `	o := $.ampersand$$.type|raw${}
+`, options)
+	return named("EmptyConstructor", raw, args)
 }
 
+// GenerateConstructorForObjectMeta generates a constructor setting the name and, unless kind is empty, the TypeMeta.
+func GenerateConstructorForObjectMeta(t *types.Type, apiVersion, kind string, options bool) (string, generator.Args) {
+	args := defaultGeneratorArgs(t, true)
+	args["apiVersion"] = strconv.Quote(apiVersion)
+	args["kind"] = strconv.Quote(kind)
+
+	typeMeta := ""
+	if kind != "" {
+		typeMeta = `	o.TypeMeta.APIVersion = $.apiVersion$
+	o.TypeMeta.Kind = $.kind$
 `
-	return raw, args
+	}
+
+	raw := constructor("New$.type|raw$", []string{"name string"}, `	o := &$.type|raw${}
+	o.ObjectMeta.Name = name
+`+typeMeta, options)
+	return named("ConstructorForObjectMeta", raw, args)
+}
+
+// GenerateConstructorWithRequired generates a constructor taking the required members of the parent type as parameters.
+func GenerateConstructorWithRequired(t *types.Type, parent *types.Type, required []types.Member, options bool) (string, generator.Args) {
+	args := defaultGeneratorArgs(t, true)
+	args["members"] = required
+
+	params := []string{}
+	assignments := ""
+	for i, m := range required {
+		name := paramName(m.Name)
+		memberType := fmt.Sprintf("memberType%d", i)
+		args[memberType] = m.Type
+		params = append(params, fmt.Sprintf("%s $.%s|raw$", name, memberType))
+		assignments += fmt.Sprintf("\to.%s = %s\n", memberAccessor(t, parent, m), name)
+	}
+
+	raw := constructor("New$.type|raw$", params, "\to := &$.type|raw${}\n"+assignments, options)
+	return named("ConstructorWithRequired", raw, args)
+}
+
+// GenerateConstructorInNamespace generates a constructor of namespaced resources calling the ObjectMeta constructor.
+// With options, the namespace is set before the options are applied.
+func GenerateConstructorInNamespace(t *types.Type, options bool) (string, generator.Args) {
+	args := defaultGeneratorArgs(t, true)
+
+	raw := constructor("New$.type|raw$InNamespace", []string{"namespace, name string"}, `	o := New$.type|raw$(name)
+	o.ObjectMeta.Namespace = namespace
+`, options)
+	return named("ConstructorInNamespace", raw, args)
 }
 
-func GenerateConstructorForObjectMeta(t *types.Type) (string, generator.Args) {
+// GenerateConstructorFrom generates a constructor wrapping a deep copy of an existing parent object, e.g. fetched from the API server.
+func GenerateConstructorFrom(t *types.Type, parent *types.Type) (string, generator.Args) {
 	args := defaultGeneratorArgs(t, true)
+	args["parent"] = parent
+	args["parentName"] = parent.Name.Name
 
-	raw := `// New$.type|raw$ is an autogenerated constructor.
-func New$.type|raw$(name string) *$.type|raw$ {
+	raw := `// $.type|raw$From is an autogenerated constructor wrapping a deep copy of obj.
+func $.type|raw$From(obj *$.parent|raw$) *$.type|raw$ {
+	if obj == nil {
+		return nil
+	}
 	o := &$.type|raw${}
-	o.ObjectMeta.Name = name
+	obj.DeepCopyInto(&o.$.parentName$)
 	return o
 }
 
 `
-	return raw, args
+	return named("ConstructorFrom", raw, args)
+}
+
+// GenerateWrapConstructor generates a constructor wrapping an existing parent object without a deep copy.
+func GenerateWrapConstructor(t *types.Type, parent *types.Type) (string, generator.Args) {
+	args := defaultGeneratorArgs(t, true)
+	args["parent"] = parent
+	args["parentName"] = parent.Name.Name
+
+	raw := `// Wrap$.type|raw$ is an autogenerated constructor wrapping obj without a deep copy, maps, slices and pointers are shared with obj.
+func Wrap$.type|raw$(obj *$.parent|raw$) *$.type|raw$ {
+	if obj == nil {
+		return nil
+	}
+	return &$.type|raw${$.parentName$: *obj}
+}
+
+`
+	return named("WrapConstructor", raw, args)
+}
+
+// constructor returns a constructor with the body initializing o.
+// With options, the constructor takes functional options and applies them after the body.
+func constructor(name string, params []string, body string, options bool) string {
+	if options {
+		params = append(params, "opts ...$.type|raw$Option")
+		body += `	for _, opt := range opts {
+		opt(o)
+	}
+`
+	}
+
+	return `// ` + name + ` is an autogenerated constructor.
+func ` + name + `(` + strings.Join(params, ", ") + `) $.pointer$$.type|raw$ {
+` + body + `	return o
+}
+
+`
+}
+
+// paramName lower cases the leading upper case letters of a member name, e.g. HTTPGet becomes httpGet.
+// Names colliding with Go keywords, predeclared identifiers or the receiver are prefixed with "in".
+func paramName(member string) string {
+	runes := []rune(member)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			break
+		}
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	name := string(runes)
+	if token.Lookup(name).IsKeyword() || gotypes.Universe.Lookup(name) != nil || name == "o" {
+		return "in" + member
+	}
+	return name
 }
 
 func defaultGeneratorArgs(t *types.Type, pointerReceiver bool) generator.Args {
@@ -20,6 +20,7 @@ func TestGenerateEmptyConstructor(t *testing.T) {
 		description     string
 		testType        *types.Type
 		pointerReceiver bool
+		options         bool
 		want            string
 	}{
 		{
@@ -44,6 +45,21 @@ func NewSomeStruct() *SomeStruct {
 	return o
 }
 
+`,
+		},
+		{
+			description: "Constructor applies functional options",
+			testType:    newTestType(t, "SomeStruct"),
+			options:     true,
+			want: `// NewSomeStruct is an autogenerated constructor.
+func NewSomeStruct(opts ...SomeStructOption) *SomeStruct {
+	o := &SomeStruct{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
 `,
 		},
 	}
@@ -51,7 +67,7 @@ func NewSomeStruct() *SomeStruct {
 	for _, test := range tests {
 		var b bytes.Buffer
 		sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
-		sw.Do(GenerateEmptyConstructor(test.testType, test.pointerReceiver))
+		sw.Do(GenerateEmptyConstructor(test.testType, test.pointerReceiver, test.options))
 		assert.NoError(t, sw.Error(), test.description)
 		assert.Equal(t, test.want, b.String(), test.description)
 	}
@@ -64,21 +80,185 @@ func TestGenerateConstructorForObjectMeta(t *testing.T) {
 	require.NoError(t, err)
 
 	testType := newTestType(t, "SomeStruct")
-	want := `// NewSomeStruct is an autogenerated constructor.
+
+	tests := []struct {
+		description string
+		apiVersion  string
+		kind        string
+		options     bool
+		want        string
+	}{
+		{
+			description: "unknown type meta",
+			want: `// NewSomeStruct is an autogenerated constructor.
 func NewSomeStruct(name string) *SomeStruct {
 	o := &SomeStruct{}
 	o.ObjectMeta.Name = name
 	return o
 }
 
+`,
+		},
+		{
+			description: "populate type meta",
+			apiVersion:  "apps/v1",
+			kind:        "Deployment",
+			want: `// NewSomeStruct is an autogenerated constructor.
+func NewSomeStruct(name string) *SomeStruct {
+	o := &SomeStruct{}
+	o.ObjectMeta.Name = name
+	o.TypeMeta.APIVersion = "apps/v1"
+	o.TypeMeta.Kind = "Deployment"
+	return o
+}
+
+`,
+		},
+		{
+			description: "apply functional options",
+			options:     true,
+			want: `// NewSomeStruct is an autogenerated constructor.
+func NewSomeStruct(name string, opts ...SomeStructOption) *SomeStruct {
+	o := &SomeStruct{}
+	o.ObjectMeta.Name = name
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+`,
+		},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+		sw.Do(GenerateConstructorForObjectMeta(testType, test.apiVersion, test.kind, test.options))
+		assert.NoError(t, sw.Error(), test.description)
+		assert.Equal(t, test.want, b.String(), test.description)
+	}
+}
+
+func TestGenerateConstructorInNamespace(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	testType := newTestType(t, "SomeStruct")
+	want := `// NewSomeStructInNamespace is an autogenerated constructor.
+func NewSomeStructInNamespace(namespace, name string) *SomeStruct {
+	o := NewSomeStruct(name)
+	o.ObjectMeta.Namespace = namespace
+	return o
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateConstructorInNamespace(testType, false))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestGenerateConstructorWithRequired(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	testType := newTestType(t, "CStruct")
+	parent := getMemberFromType(t, testType, "CStruct").Type
+	member := getMemberFromType(t, testType, "CStruct", "Int")
+
+	want := `// NewCStruct is an autogenerated constructor.
+func NewCStruct(inInt int) *CStruct {
+	o := &CStruct{}
+	o.CStruct.Int = inInt
+	return o
+}
+
 `
 	var b bytes.Buffer
 	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
-	sw.Do(GenerateConstructorForObjectMeta(testType))
+	sw.Do(GenerateConstructorWithRequired(testType, parent, []types.Member{member}, false))
 	assert.NoError(t, sw.Error())
 	assert.Equal(t, want, b.String())
 }
 
+func TestGenerateWrapConstructors(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	testType := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, testType, "SomeStruct").Type
+
+	want := `// SomeStructFrom is an autogenerated constructor wrapping a deep copy of obj.
+func SomeStructFrom(obj *a.SomeStruct) *SomeStruct {
+	if obj == nil {
+		return nil
+	}
+	o := &SomeStruct{}
+	obj.DeepCopyInto(&o.SomeStruct)
+	return o
+}
+
+// WrapSomeStruct is an autogenerated constructor wrapping obj without a deep copy, maps, slices and pointers are shared with obj.
+func WrapSomeStruct(obj *a.SomeStruct) *SomeStruct {
+	if obj == nil {
+		return nil
+	}
+	return &SomeStruct{SomeStruct: *obj}
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateConstructorFrom(testType, parent))
+	sw.Do(GenerateWrapConstructor(testType, parent))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestGenerateOptionType(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	testType := newTestType(t, "SomeStruct")
+	want := `// SomeStructOption is an autogenerated functional option of NewSomeStruct.
+type SomeStructOption func(*SomeStruct)
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateOptionType(testType))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestParamName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"Name":          "name",
+		"ContainerPort": "containerPort",
+		"HTTPGet":       "httpGet",
+		"URL":           "url",
+		"Type":          "inType",
+		"String":        "inString",
+		"O":             "inO",
+	}
+
+	for member, want := range tests {
+		assert.Equal(t, want, paramName(member), member)
+	}
+}
+
 func TestDefaultGeneratorArgs(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,18 @@
+package snippets
+
+import "k8s.io/gengo/generator"
+
+func GenerateContainsString() (string, generator.Args) {
+	raw := `// containsString returns true if the list contains the value.
+func containsString(list []string, value string) bool {
+	for _, l := range list {
+		if l == value {
+			return true
+		}
+	}
+	return false
+}
+
+`
+	return named("ContainsString", raw, nil)
+}
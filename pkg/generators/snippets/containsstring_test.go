@@ -0,0 +1,34 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+)
+
+func TestGenerateContainsString(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	want := `// containsString returns true if the list contains the value.
+func containsString(list []string, value string) bool {
+	for _, l := range list {
+		if l == value {
+			return true
+		}
+	}
+	return false
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateContainsString())
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
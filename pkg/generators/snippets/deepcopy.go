@@ -7,9 +7,12 @@ import (
 	"k8s.io/gengo/types"
 )
 
-func GenerateDeepCopy(t *types.Type) (string, generator.Args) {
+// GenerateDeepCopy generates DeepCopy and DeepCopyInto copying the embedded parent and the members with a DeepCopyInto method.
+func GenerateDeepCopy(t *types.Type, parent *types.Type) (string, generator.Args) {
 	args := generator.Args{
-		"type": t,
+		"type":       t,
+		"parent":     parent,
+		"parentName": parent.Name.Name,
 	}
 
 	raw := `// DeepCopy is an autogenerated function
@@ -24,19 +27,44 @@ func (in *$.type|raw$) DeepCopy() *$.type|raw$ {
 
 // DeepCopyInto is an autogenerated function
 func (in *$.type|raw$) DeepCopyInto(out *$.type|raw$) {
-	in.$.type|raw$.DeepCopyInto(&out.$.type|raw$)`
+	in.$.parentName$.DeepCopyInto(&out.$.parentName$)`
 
+	members := []string{}
 	for _, m := range t.Members {
+		if m.Embedded && m.Type == parent {
+			continue
+		}
 		if m.Type.Kind == types.Struct {
 			if hasDeepCopyIntoMethod(m.Type) {
 				raw = fmt.Sprintf("%s\n\tin.%s.DeepCopyInto(&out.%s)", raw, m.Name, m.Name)
+				members = append(members, m.Name)
 			}
 		}
 	}
+	args["members"] = members
 
 	raw = fmt.Sprintf("%s\n}\n\n", raw)
 
-	return raw, args
+	return named("DeepCopy", raw, args)
+}
+
+// GenerateDeepCopyObject generates a DeepCopyObject returning a copy of the wrapper, so that the wrapper is a runtime.Object itself.
+func GenerateDeepCopyObject(t *types.Type) (string, generator.Args) {
+	args := generator.Args{
+		"type":   t,
+		"object": types.Ref(runtimePackage, "Object"),
+	}
+
+	raw := `// DeepCopyObject is an autogenerated function
+func (in *$.type|raw$) DeepCopyObject() $.object|raw$ {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+`
+	return named("DeepCopyObject", raw, args)
 }
 
 func hasDeepCopyIntoMethod(t *types.Type) bool {
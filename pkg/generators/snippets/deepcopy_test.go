@@ -17,13 +17,15 @@ func TestGenerateDeepCopy(t *testing.T) {
 	require.NoError(t, err)
 
 	tests := []struct {
-		ctx  *generator.Context
-		typ  *types.Type
-		want string
+		ctx    *generator.Context
+		typ    *types.Type
+		parent string
+		want   string
 	}{
 		{
-			ctx: ctx,
-			typ: newTestType(t, "SomeStruct"),
+			ctx:    ctx,
+			typ:    newTestType(t, "SomeStruct"),
+			parent: "SomeStruct",
 			want: `// DeepCopy is an autogenerated function
 func (in *SomeStruct) DeepCopy() *SomeStruct {
 	if in == nil {
@@ -43,8 +45,9 @@ func (in *SomeStruct) DeepCopyInto(out *SomeStruct) {
 		},
 
 		{
-			ctx: ctx,
-			typ: newTestType(t, "CopyStruct"),
+			ctx:    ctx,
+			typ:    newTestType(t, "CopyStruct"),
+			parent: "SomeStruct",
 			want: `// DeepCopy is an autogenerated function
 func (in *CopyStruct) DeepCopy() *CopyStruct {
 	if in == nil {
@@ -57,7 +60,7 @@ func (in *CopyStruct) DeepCopy() *CopyStruct {
 
 // DeepCopyInto is an autogenerated function
 func (in *CopyStruct) DeepCopyInto(out *CopyStruct) {
-	in.CopyStruct.DeepCopyInto(&out.CopyStruct)
+	in.SomeStruct.DeepCopyInto(&out.SomeStruct)
 	in.ComplexStruct.DeepCopyInto(&out.ComplexStruct)
 }
 
@@ -68,8 +71,30 @@ func (in *CopyStruct) DeepCopyInto(out *CopyStruct) {
 	for _, test := range tests {
 		var b bytes.Buffer
 		sw := generator.NewSnippetWriter(&b, test.ctx, "$", "$")
-		sw.Do(GenerateDeepCopy(test.typ))
+		sw.Do(GenerateDeepCopy(test.typ, getMemberFromType(t, test.typ, test.parent).Type))
 		assert.NoError(t, sw.Error())
 		assert.Equal(t, test.want, b.String())
 	}
 }
+
+func TestGenerateDeepCopyObject(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	want := `// DeepCopyObject is an autogenerated function
+func (in *SomeStruct) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateDeepCopyObject(newTestType(t, "SomeStruct")))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
@@ -2,6 +2,8 @@ package snippets
 
 import (
 	"fmt"
+	"go/token"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -14,22 +16,228 @@ import (
 const allValue = "*"
 const allSuffix = "All"
 
-func GenerateEnumSetter(inputType *types.Type, enumOptions []string) (string, generator.Args) {
+// EnumConstant describes a generated enum constant for template overrides.
+type EnumConstant struct {
+	Name    string
+	Type    string
+	Literal string
+}
+
+// EnumOption is an enum constant and its value.
+type EnumOption struct {
+	Name  string
+	Value string
+}
+
+// ParseEnumOptions parses the entries of an enum argument, either a bare value or <Suffix>:<value> to name the constant explicitly,
+// e.g. DockerConfigJSON:kubernetes.io/dockerconfigjson. Entries not starting with an exported Go identifier followed by a colon,
+// e.g. http://example.com, are bare values. Constants are named after the type and the suffix.
+func ParseEnumOptions(t *types.Type, entries []string) []EnumOption {
+	out := []EnumOption{}
+	for _, val := range entries {
+		if val == "" {
+			continue
+		}
+
+		suffix, value, found := strings.Cut(val, ":")
+		if !found || !token.IsIdentifier(suffix) || !token.IsExported(suffix) {
+			out = append(out, EnumOption{Name: t.Name.Name + toSuffix(val), Value: val})
+			continue
+		}
+		out = append(out, EnumOption{Name: t.Name.Name + suffix, Value: value})
+	}
+	return out
+}
+
+// ValidateEnumOptions returns an error if an enum value is not a literal of the underlying type, does not produce
+// a valid constant name or produces the same constant name as another value or as a generated helper.
+func ValidateEnumOptions(inputType *types.Type, enumOptions []EnumOption) error {
+	underlying := enumUnderlying(inputType)
+	values := map[string]string{}
+	for _, name := range EnumHelperNames(inputType) {
+		values[name] = "generated helper"
+	}
+
+	for _, o := range enumOptions {
+		name := o.Name
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("enum value %q of %s generates the invalid constant name %q, name it with <Suffix>:<value>", o.Value, inputType.Name, name)
+		}
+
+		if other, ok := values[name]; ok {
+			return fmt.Errorf("enum values %q and %q of %s both generate the constant %s, name one of them with <Suffix>:<value>", other, o.Value, inputType.Name, name)
+		}
+		values[name] = fmt.Sprintf("%q", o.Value)
+
+		if _, err := enumLiteral(underlying, o.Value); err != nil {
+			return fmt.Errorf("enum value %q of %s: %w", o.Value, inputType.Name, err)
+		}
+	}
+	return nil
+}
+
+// EnumHelperNames returns the package level names declared by GenerateEnumHelpers, which constants must not use.
+func EnumHelperNames(t *types.Type) []string {
+	return []string{t.Name.Name + "Values", "Parse" + t.Name.Name}
+}
+
+func GenerateEnumSetter(inputType *types.Type, enumOptions []EnumOption) (string, generator.Args) {
 	args := generator.Args{
 		"type": inputType,
 		"name": inputType.Name.Name,
 	}
 
+	underlying := enumUnderlying(inputType)
+	literals := []string{}
+	constants := []EnumConstant{}
 	raw := ""
 
-	for _, val := range enumOptions {
-		if val != "" {
-			raw += fmt.Sprintf(`const $.name$%s $.name$ = "%s"`, toSuffix(val), val)
-			raw += "\n"
+	for _, o := range enumOptions {
+		literal, err := enumLiteral(underlying, o.Value)
+		if err != nil {
+			// invalid values are reported by ValidateEnumOptions
+			continue
+		}
+		raw += fmt.Sprintf("const %s $.name$ = $index .literals %d$\n", o.Name, len(literals))
+		literals = append(literals, literal)
+		constants = append(constants, EnumConstant{Name: o.Name, Type: inputType.Name.Name, Literal: literal})
+	}
+
+	args["literals"] = literals
+	args["constants"] = constants
+	return named("EnumSetter", raw, args)
+}
+
+// GenerateEnumHelpers generates a Values function, validation, parsing and text marshaling for an enum type.
+// Values lists the first constant of every value. Nothing is generated without enum values.
+func GenerateEnumHelpers(inputType *types.Type, enumOptions []EnumOption) (string, generator.Args) {
+	underlying := enumUnderlying(inputType)
+	args := generator.Args{
+		"type":       inputType,
+		"name":       inputType.Name.Name,
+		"underlying": underlying,
+	}
+
+	constants := []string{}
+	seen := map[string]bool{}
+	for _, o := range enumOptions {
+		if literal, err := enumLiteral(underlying, o.Value); err == nil && !seen[literal] {
+			seen[literal] = true
+			constants = append(constants, o.Name)
+		}
+	}
+
+	args["constants"] = constants
+
+	if len(constants) == 0 {
+		return "", args
+	}
+
+	str := "string(in)"
+	if underlying != types.String {
+		str = "fmt.Sprint($.underlying|raw$(in))"
+	}
+
+	raw := fmt.Sprintf(`
+// $.name$Values is an autogenerated function returning every $.name$ value.
+func $.name$Values() []$.name$ {
+	return []$.name${%s}
+}
+
+// IsValid is an autogenerated function
+func (in $.name$) IsValid() bool {
+	for _, v := range $.name$Values() {
+		if in == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String is an autogenerated function
+func (in $.name$) String() string {
+	return %s
+}
+
+// Parse$.name$ is an autogenerated function
+func Parse$.name$(in string) ($.name$, error) {
+	for _, v := range $.name$Values() {
+		if v.String() == in {
+			return v, nil
+		}
+	}
+	var out $.name$
+	return out, fmt.Errorf("invalid $.name$ %%q, must be one of %%v", in, $.name$Values())
+}
+
+// MarshalText is an autogenerated function
+func (in $.name$) MarshalText() ([]byte, error) {
+	return []byte(in.String()), nil
+}
+
+// UnmarshalText is an autogenerated function
+func (in *$.name$) UnmarshalText(text []byte) error {
+	out, err := Parse$.name$(string(text))
+	if err != nil {
+		return err
+	}
+	*in = out
+	return nil
+}
+
+`, strings.Join(constants, ", "), str)
+
+	return named("EnumHelpers", raw, args)
+}
+
+// enumUnderlying returns the builtin type of an enum, defaulting to string.
+func enumUnderlying(t *types.Type) *types.Type {
+	if t.Kind == types.Builtin {
+		return t
+	}
+	if t.Underlying != nil && t.Underlying.Kind == types.Builtin {
+		return t.Underlying
+	}
+	return types.String
+}
+
+// enumLiteral returns the Go literal of an enum value for the builtin type.
+func enumLiteral(underlying *types.Type, value string) (string, error) {
+	name := underlying.Name.Name
+	switch {
+	case name == "string":
+		return strconv.Quote(value), nil
+	case name == "bool":
+		if value != "true" && value != "false" {
+			return "", fmt.Errorf("must be true or false")
+		}
+		return value, nil
+	case strings.HasPrefix(name, "int"):
+		if _, err := strconv.ParseInt(value, 0, bitSize(name, "int")); err != nil {
+			return "", fmt.Errorf("is not a valid %s", name)
 		}
+		return value, nil
+	case strings.HasPrefix(name, "uint") || name == "byte":
+		if _, err := strconv.ParseUint(value, 0, bitSize(strings.Replace(name, "byte", "uint8", 1), "uint")); err != nil {
+			return "", fmt.Errorf("is not a valid %s", name)
+		}
+		return value, nil
+	case strings.HasPrefix(name, "float"):
+		if _, err := strconv.ParseFloat(value, bitSize(name, "float")); err != nil {
+			return "", fmt.Errorf("is not a valid %s", name)
+		}
+		return value, nil
+	default:
+		return "", fmt.Errorf("enums of %s are not supported", name)
 	}
+}
 
-	return raw, args
+func bitSize(name, prefix string) int {
+	size, err := strconv.Atoi(strings.TrimPrefix(name, prefix))
+	if err != nil {
+		return 64
+	}
+	return size
 }
 
 func toSuffix(v string) string {
@@ -50,6 +50,26 @@ func TestGenerateEnumSetter(t *testing.T) {
 			enumVals:    []string{"kubernetes.io/test-enum-value", "val2"},
 			want:        "const MyEnumTestEnumValue MyEnum = \"kubernetes.io/test-enum-value\"\nconst MyEnumVal2 MyEnum = \"val2\"\n",
 		},
+		{
+			description: "named value",
+			enumVals:    []string{"Docker:kubernetes.io/dockercfg"},
+			want:        "const MyEnumDocker MyEnum = \"kubernetes.io/dockercfg\"\n",
+		},
+		{
+			description: "named values",
+			enumVals:    []string{"DockerConfigJSON:kubernetes.io/dockerconfigjson", "DockerCfg:kubernetes.io/dockercfg"},
+			want:        "const MyEnumDockerConfigJSON MyEnum = \"kubernetes.io/dockerconfigjson\"\nconst MyEnumDockerCfg MyEnum = \"kubernetes.io/dockercfg\"\n",
+		},
+		{
+			description: "colon in value",
+			enumVals:    []string{"http://example"},
+			want:        "const MyEnumExample MyEnum = \"http://example\"\n",
+		},
+		{
+			description: "quoted value",
+			enumVals:    []string{`Quote:a"b`},
+			want:        "const MyEnumQuote MyEnum = \"a\\\"b\"\n",
+		},
 		{
 			description: "any namespace",
 			enumVals:    []string{"code-generator/test-enum-value", "val2"},
@@ -62,12 +82,266 @@ func TestGenerateEnumSetter(t *testing.T) {
 	for _, test := range tests {
 		var b bytes.Buffer
 		sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
-		sw.Do(GenerateEnumSetter(&tt, test.enumVals))
+		sw.Do(GenerateEnumSetter(&tt, ParseEnumOptions(&tt, test.enumVals)))
 		assert.NoError(t, sw.Error(), test.description)
 		assert.Equal(t, test.want, b.String(), test.description)
 	}
 }
 
+func TestGenerateEnumHelpers(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	assert.NoError(t, err)
+
+	want := `
+// MyEnumValues is an autogenerated function returning every MyEnum value.
+func MyEnumValues() []MyEnum {
+	return []MyEnum{MyEnumAll, MyEnumTestEnumValue}
+}
+
+// IsValid is an autogenerated function
+func (in MyEnum) IsValid() bool {
+	for _, v := range MyEnumValues() {
+		if in == v {
+			return true
+		}
+	}
+	return false
+}
+
+// String is an autogenerated function
+func (in MyEnum) String() string {
+	return string(in)
+}
+
+// ParseMyEnum is an autogenerated function
+func ParseMyEnum(in string) (MyEnum, error) {
+	for _, v := range MyEnumValues() {
+		if v.String() == in {
+			return v, nil
+		}
+	}
+	var out MyEnum
+	return out, fmt.Errorf("invalid MyEnum %q, must be one of %v", in, MyEnumValues())
+}
+
+// MarshalText is an autogenerated function
+func (in MyEnum) MarshalText() ([]byte, error) {
+	return []byte(in.String()), nil
+}
+
+// UnmarshalText is an autogenerated function
+func (in *MyEnum) UnmarshalText(text []byte) error {
+	out, err := ParseMyEnum(string(text))
+	if err != nil {
+		return err
+	}
+	*in = out
+	return nil
+}
+
+`
+
+	tt := enumTestType()
+
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateEnumHelpers(&tt, ParseEnumOptions(&tt, []string{"*", "kubernetes.io/test-enum-value"})))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+
+	b.Reset()
+	sw.Do(GenerateEnumHelpers(&tt, ParseEnumOptions(&tt, []string{""})))
+	assert.NoError(t, sw.Error())
+	assert.Empty(t, b.String(), "no helpers without enum values")
+}
+
+func TestGenerateEnumSetterTyped(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		description string
+		underlying  *types.Type
+		enumVals    []string
+		want        string
+	}{
+		{
+			description: "int values",
+			underlying:  types.Int32,
+			enumVals:    []string{"Pending:0", "Running:1", "Failed:-1", "0x10"},
+			want:        "const MyEnumPending MyEnum = 0\nconst MyEnumRunning MyEnum = 1\nconst MyEnumFailed MyEnum = -1\nconst MyEnum0X10 MyEnum = 0x10\n",
+		},
+		{
+			description: "float values",
+			underlying:  types.Float64,
+			enumVals:    []string{"Half:0.5"},
+			want:        "const MyEnumHalf MyEnum = 0.5\n",
+		},
+		{
+			description: "bool values",
+			underlying:  types.Bool,
+			enumVals:    []string{"On:true", "Off:false"},
+			want:        "const MyEnumOn MyEnum = true\nconst MyEnumOff MyEnum = false\n",
+		},
+	}
+
+	for _, test := range tests {
+		tt := enumTestType()
+		tt.Kind = types.Alias
+		tt.Underlying = test.underlying
+
+		var b bytes.Buffer
+		sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+		sw.Do(GenerateEnumSetter(&tt, ParseEnumOptions(&tt, test.enumVals)))
+		assert.NoError(t, sw.Error(), test.description)
+		assert.Equal(t, test.want, b.String(), test.description)
+	}
+}
+
+func TestGenerateEnumConstants(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	assert.NoError(t, err)
+
+	tt := enumTestType()
+	options := []EnumOption{
+		{Name: "MyEnumTCP", Value: "TCP"},
+		{Name: "MyEnumEq", Value: "key=value"},
+		{Name: "DefaultMyEnum", Value: "TCP"},
+	}
+
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateEnumSetter(&tt, options))
+	sw.Do(GenerateEnumHelpers(&tt, options))
+	assert.NoError(t, sw.Error())
+	assert.Contains(t, b.String(), `const MyEnumTCP MyEnum = "TCP"
+const MyEnumEq MyEnum = "key=value"
+const DefaultMyEnum MyEnum = "TCP"
+`, "constants are not parsed as enum entries")
+	assert.Contains(t, b.String(), "return []MyEnum{MyEnumTCP, MyEnumEq}", "values are listed once")
+	assert.NoError(t, ValidateEnumOptions(&tt, options))
+}
+
+func TestGenerateEnumHelpersTyped(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	assert.NoError(t, err)
+
+	tt := enumTestType()
+	tt.Kind = types.Alias
+	tt.Underlying = types.Int
+
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateEnumHelpers(&tt, ParseEnumOptions(&tt, []string{"Pending:0", "Running:1"})))
+	assert.NoError(t, sw.Error())
+	assert.Contains(t, b.String(), "return []MyEnum{MyEnumPending, MyEnumRunning}")
+	assert.Contains(t, b.String(), `func (in MyEnum) String() string {
+	return fmt.Sprint(int(in))
+}`)
+}
+
+func TestValidateEnumOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		underlying  *types.Type
+		enumVals    []string
+		wantErr     bool
+	}{
+		{
+			description: "string values",
+			underlying:  types.String,
+			enumVals:    []string{"*", "kubernetes.io/test-enum-value", ""},
+		},
+		{
+			description: "int values",
+			underlying:  types.Int64,
+			enumVals:    []string{"Pending:0", "Failed:-1"},
+		},
+		{
+			description: "value is not an int",
+			underlying:  types.Int,
+			enumVals:    []string{"Pending:zero"},
+			wantErr:     true,
+		},
+		{
+			description: "value overflows",
+			underlying:  types.Byte,
+			enumVals:    []string{"Big:256"},
+			wantErr:     true,
+		},
+		{
+			description: "value is not a bool",
+			underlying:  types.Bool,
+			enumVals:    []string{"On:1"},
+			wantErr:     true,
+		},
+		{
+			description: "named values",
+			underlying:  types.String,
+			enumVals:    []string{"DockerConfigJSON:kubernetes.io/dockerconfigjson", "DockerCfg:kubernetes.io/dockercfg"},
+		},
+		{
+			description: "suffix collision",
+			underlying:  types.String,
+			enumVals:    []string{"kubernetes.io/tls", "example.com/tls"},
+			wantErr:     true,
+		},
+		{
+			description: "named suffix collision",
+			underlying:  types.String,
+			enumVals:    []string{"Tls:kubernetes.io/tls", "tls"},
+			wantErr:     true,
+		},
+		{
+			description: "invalid named suffix",
+			underlying:  types.Int,
+			enumVals:    []string{"my-value:1"},
+			wantErr:     true,
+		},
+		{
+			description: "unexported named suffix",
+			underlying:  types.Int,
+			enumVals:    []string{"pending:0"},
+			wantErr:     true,
+		},
+		{
+			description: "helper name",
+			underlying:  types.String,
+			enumVals:    []string{"Values:values"},
+			wantErr:     true,
+		},
+		{
+			description: "invalid constant name",
+			underlying:  types.Int,
+			enumVals:    []string{"-1"},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		tt := enumTestType()
+		tt.Kind = types.Alias
+		tt.Underlying = test.underlying
+
+		err := ValidateEnumOptions(&tt, ParseEnumOptions(&tt, test.enumVals))
+		if test.wantErr {
+			assert.Error(t, err, test.description)
+		} else {
+			assert.NoError(t, err, test.description)
+		}
+	}
+}
+
 func enumTestType() types.Type {
 	tt := types.Type{
 		Name: types.Name{
@@ -0,0 +1,196 @@
+package snippets
+
+import (
+	"fmt"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+type Getter struct {
+	Root   *types.Type
+	Parent *types.Type
+}
+
+func NewGetter(root, parent *types.Type) *Getter {
+	return &Getter{
+		Root:   root,
+		Parent: parent,
+	}
+}
+
+func (g *Getter) GenerateGetterForType(member types.Member) (string, generator.Args) {
+	args := g.args(member)
+	args["memberType"] = member.Type
+
+	raw := `// $.funcName$ is an autogenerated function
+func (o *$.type|raw$) $.funcName$() (out $.memberType|raw$) {
+	if o == nil {
+		return
+	}
+	return o.$.memberAccessor$
+}
+
+`
+	return named("GetterForType", raw, args)
+}
+
+func (g *Getter) GenerateGetterForPointerToBuiltinType(member types.Member) (string, generator.Args) {
+	args := g.args(member)
+	args["memberElemType"] = member.Type.Elem
+
+	raw := `// $.funcName$ is an autogenerated function
+func (o *$.type|raw$) $.funcName$() (out $.memberElemType|raw$) {
+	if o == nil || o.$.memberAccessor$ == nil {
+		return
+	}
+	return *o.$.memberAccessor$
+}
+
+`
+	return named("GetterForPointerToBuiltinType", raw, args)
+}
+
+func (g *Getter) GenerateGetterForTypeEnum(member types.Member, argType *types.Type) (string, generator.Args) {
+	args := g.args(member)
+	args["argType"] = argType
+
+	raw := `// $.funcName$ is an autogenerated function
+func (o *$.type|raw$) $.funcName$() (out $.argType|raw$) {
+	if o == nil {
+		return
+	}
+	return $.argType|raw$(o.$.memberAccessor$)
+}
+
+`
+	return named("GetterForTypeEnum", raw, args)
+}
+
+func (g *Getter) GenerateGetterForAliasPointerPrimitive(member types.Member, argType *types.Type) (string, generator.Args) {
+	args := g.args(member)
+	args["argType"] = argType
+
+	raw := `// $.funcName$ is an autogenerated function
+func (o *$.type|raw$) $.funcName$() (out $.argType|raw$) {
+	if o == nil || o.$.memberAccessor$ == nil {
+		return
+	}
+	return $.argType|raw$(*o.$.memberAccessor$)
+}
+
+`
+	return named("GetterForAliasPointerPrimitive", raw, args)
+}
+
+func (g *Getter) GenerateGetterForEmbeddedSliceEnum(member types.Member, argType *types.Type) (string, generator.Args) {
+	args := g.args(member)
+	args["argType"] = argType
+
+	raw := `// $.funcName$ is an autogenerated function
+func (o *$.type|raw$) $.funcName$() (out []$.argType|raw$) {
+	if o == nil {
+		return
+	}
+	for _, elem := range o.$.memberAccessor$ {
+		out = append(out, $.argType|raw$(elem))
+	}
+	return
+}
+
+`
+	return named("GetterForEmbeddedSliceEnum", raw, args)
+}
+
+func (g *Getter) GenerateGetterForEmbeddedStruct(member types.Member, wrapperType *types.Type) (string, generator.Args) {
+	args := g.args(member)
+	args["wrapperType"] = wrapperType
+	args["structType"] = member.Type.Name.Name
+
+	raw := `// $.funcName$ is an autogenerated function that returns a shallow copy of the member as a builder, maps, slices and pointers are shared with o.
+func (o *$.type|raw$) $.funcName$() *$.wrapperType|raw$ {
+	if o == nil {
+		return nil
+	}
+	return &$.wrapperType|raw${$.structType$: o.$.memberAccessor$}
+}
+
+`
+	return named("GetterForEmbeddedStruct", raw, args)
+}
+
+func (g *Getter) GenerateGetterForEmbeddedPointer(member types.Member, wrapperType *types.Type) (string, generator.Args) {
+	args := g.args(member)
+	args["wrapperType"] = wrapperType
+	args["structType"] = member.Type.Elem.Name.Name
+
+	raw := `// $.funcName$ is an autogenerated function that returns a shallow copy of the member as a builder, maps, slices and pointers are shared with o.
+func (o *$.type|raw$) $.funcName$() *$.wrapperType|raw$ {
+	if o == nil || o.$.memberAccessor$ == nil {
+		return nil
+	}
+	return &$.wrapperType|raw${$.structType$: *o.$.memberAccessor$}
+}
+
+`
+	return named("GetterForEmbeddedPointer", raw, args)
+}
+
+func (g *Getter) GenerateGetterForEmbeddedSlice(member types.Member, wrapperType *types.Type) (string, generator.Args) {
+	args := g.args(member)
+	args["wrapperType"] = wrapperType
+	args["sliceType"] = member.Type.Elem.Name.Name
+
+	raw := `// $.funcName$ is an autogenerated function that returns a shallow copy of each element as a builder, maps, slices and pointers are shared with o.
+func (o *$.type|raw$) $.funcName$() (out []*$.wrapperType|raw$) {
+	if o == nil {
+		return
+	}
+	for _, elem := range o.$.memberAccessor$ {
+		out = append(out, &$.wrapperType|raw${$.sliceType$: elem})
+	}
+	return
+}
+
+`
+	return named("GetterForEmbeddedSlice", raw, args)
+}
+
+func (g *Getter) GenerateGetterForEmbeddedSlicePointer(member types.Member, wrapperType *types.Type) (string, generator.Args) {
+	args := g.args(member)
+	args["wrapperType"] = wrapperType
+	args["sliceType"] = member.Type.Elem.Elem.Name.Name
+
+	raw := `// $.funcName$ is an autogenerated function that returns a shallow copy of each non-nil element as a builder, maps, slices and pointers are shared with o.
+func (o *$.type|raw$) $.funcName$() (out []*$.wrapperType|raw$) {
+	if o == nil {
+		return
+	}
+	for _, elem := range o.$.memberAccessor$ {
+		if elem != nil {
+			out = append(out, &$.wrapperType|raw${$.sliceType$: *elem})
+		}
+	}
+	return
+}
+
+`
+	return named("GetterForEmbeddedSlicePointer", raw, args)
+}
+
+func (g *Getter) args(member types.Member) generator.Args {
+	args := defaultGeneratorArgs(g.Root, true)
+	args["funcName"] = getterFuncName(member)
+	args["memberAccessor"] = memberAccessor(g.Root, g.Parent, member)
+	return args
+}
+
+func getterFuncName(m types.Member) string {
+	return fmt.Sprintf("Get%s", memberFuncName(m))
+}
+
+// HasGetter returns true if the parent type already provides a getter for the member, e.g. ObjectMeta.GetName.
+func HasGetter(parent *types.Type, m types.Member) bool {
+	_, ok := parent.Methods[getterFuncName(m)]
+	return ok
+}
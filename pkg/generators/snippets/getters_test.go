@@ -0,0 +1,191 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+func TestGenerateGetters(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	cStruct := newTestType(t, "CStruct")
+	anEnum := newTestType(t, "AnEnum")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+	getter := NewGetter(someStruct, parent)
+
+	member := func(name string) types.Member {
+		return getMemberFromType(t, someStruct, "SomeStruct", name)
+	}
+
+	tests := []struct {
+		description string
+		generate    func() (string, generator.Args)
+		want        string
+	}{
+		{
+			description: "getter for type",
+			generate:    func() (string, generator.Args) { return getter.GenerateGetterForType(member("MapIntString")) },
+			want: `// GetMapIntString is an autogenerated function
+func (o *SomeStruct) GetMapIntString() (out map[int]string) {
+	if o == nil {
+		return
+	}
+	return o.SomeStruct.MapIntString
+}
+
+`,
+		},
+		{
+			description: "getter for pointer to builtin",
+			generate:    func() (string, generator.Args) { return getter.GenerateGetterForPointerToBuiltinType(member("IntPtr")) },
+			want: `// GetIntPtr is an autogenerated function
+func (o *SomeStruct) GetIntPtr() (out int) {
+	if o == nil || o.SomeStruct.IntPtr == nil {
+		return
+	}
+	return *o.SomeStruct.IntPtr
+}
+
+`,
+		},
+		{
+			description: "getter for enum",
+			generate:    func() (string, generator.Args) { return getter.GenerateGetterForTypeEnum(member("AnEnum"), anEnum) },
+			want: `// GetAnEnum is an autogenerated function
+func (o *SomeStruct) GetAnEnum() (out AnEnum) {
+	if o == nil {
+		return
+	}
+	return AnEnum(o.SomeStruct.AnEnum)
+}
+
+`,
+		},
+		{
+			description: "getter for alias pointer",
+			generate: func() (string, generator.Args) {
+				return getter.GenerateGetterForAliasPointerPrimitive(member("Alias"), anEnum)
+			},
+			want: `// GetAlias is an autogenerated function
+func (o *SomeStruct) GetAlias() (out AnEnum) {
+	if o == nil || o.SomeStruct.Alias == nil {
+		return
+	}
+	return AnEnum(*o.SomeStruct.Alias)
+}
+
+`,
+		},
+		{
+			description: "getter for slice of enums",
+			generate: func() (string, generator.Args) {
+				return getter.GenerateGetterForEmbeddedSliceEnum(member("ManyEnums"), anEnum)
+			},
+			want: `// GetManyEnums is an autogenerated function
+func (o *SomeStruct) GetManyEnums() (out []AnEnum) {
+	if o == nil {
+		return
+	}
+	for _, elem := range o.SomeStruct.ManyEnums {
+		out = append(out, AnEnum(elem))
+	}
+	return
+}
+
+`,
+		},
+		{
+			description: "getter for embedded struct",
+			generate: func() (string, generator.Args) {
+				return getter.GenerateGetterForEmbeddedStruct(member("CStruct"), cStruct)
+			},
+			want: `// GetCStruct is an autogenerated function that returns a shallow copy of the member as a builder, maps, slices and pointers are shared with o.
+func (o *SomeStruct) GetCStruct() *CStruct {
+	if o == nil {
+		return nil
+	}
+	return &CStruct{CStruct: o.SomeStruct.CStruct}
+}
+
+`,
+		},
+		{
+			description: "getter for embedded pointer",
+			generate: func() (string, generator.Args) {
+				return getter.GenerateGetterForEmbeddedPointer(member("PointerCStruct"), cStruct)
+			},
+			want: `// GetPointerCStruct is an autogenerated function that returns a shallow copy of the member as a builder, maps, slices and pointers are shared with o.
+func (o *SomeStruct) GetPointerCStruct() *CStruct {
+	if o == nil || o.SomeStruct.PointerCStruct == nil {
+		return nil
+	}
+	return &CStruct{CStruct: *o.SomeStruct.PointerCStruct}
+}
+
+`,
+		},
+		{
+			description: "getter for embedded slice",
+			generate: func() (string, generator.Args) {
+				return getter.GenerateGetterForEmbeddedSlice(member("CStructs"), cStruct)
+			},
+			want: `// GetCStructs is an autogenerated function that returns a shallow copy of each element as a builder, maps, slices and pointers are shared with o.
+func (o *SomeStruct) GetCStructs() (out []*CStruct) {
+	if o == nil {
+		return
+	}
+	for _, elem := range o.SomeStruct.CStructs {
+		out = append(out, &CStruct{CStruct: elem})
+	}
+	return
+}
+
+`,
+		},
+		{
+			description: "getter for embedded slice of pointers",
+			generate: func() (string, generator.Args) {
+				return getter.GenerateGetterForEmbeddedSlicePointer(member("ManyPointers"), cStruct)
+			},
+			want: `// GetManyPointers is an autogenerated function that returns a shallow copy of each non-nil element as a builder, maps, slices and pointers are shared with o.
+func (o *SomeStruct) GetManyPointers() (out []*CStruct) {
+	if o == nil {
+		return
+	}
+	for _, elem := range o.SomeStruct.ManyPointers {
+		if elem != nil {
+			out = append(out, &CStruct{CStruct: *elem})
+		}
+	}
+	return
+}
+
+`,
+		},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+		sw.Do(test.generate())
+		assert.NoError(t, sw.Error(), test.description)
+		assert.Equal(t, test.want, b.String(), test.description)
+	}
+}
+
+func TestHasGetter(t *testing.T) {
+	t.Parallel()
+
+	parent := &types.Type{Methods: map[string]*types.Type{"GetName": {}}}
+	assert.True(t, HasGetter(parent, types.Member{Name: "Name"}))
+	assert.False(t, HasGetter(parent, types.Member{Name: "Labels"}))
+}
@@ -0,0 +1,104 @@
+package snippets
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// LabelSelectorMatch locates a label selector and the template whose labels must match it.
+type LabelSelectorMatch struct {
+	// Path are the members from the parent type to the struct holding the selector and the template, empty if the parent holds them.
+	Path []types.Member
+	// Selector is a map[string]string or a struct, or pointer to struct, with a MatchLabels map.
+	Selector types.Member
+	// Template is a struct, or pointer to struct, embedding ObjectMeta.
+	Template types.Member
+}
+
+// GenerateSetterForMapKey generates a setter for a single key of a map named after the singular form of the member, e.g. WithLabel.
+func (s *Setter) GenerateSetterForMapKey(member types.Member, name string) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = fmt.Sprintf("With%s", name)
+	args["memberAccessor"] = s.memberAccessor(member)
+	args["memberType"] = member.Type
+	args["keyType"] = member.Type.Key
+	args["elemType"] = member.Type.Elem
+
+	raw := s.function(args, "key $.keyType|raw$, value $.elemType|raw$", `	if o.$.memberAccessor$ == nil {
+		o.$.memberAccessor$ = make($.memberType|raw$)
+	}
+	o.$.memberAccessor$[key] = value
+`)
+	return named("SetterForMapKey", raw, args)
+}
+
+// GenerateSetterForMapKeyRemove generates a function removing one or more keys from a map named after the singular form of the member, e.g. RemoveLabel.
+func (s *Setter) GenerateSetterForMapKeyRemove(member types.Member, name string) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = fmt.Sprintf("Remove%s", name)
+	args["memberAccessor"] = s.memberAccessor(member)
+	args["keyType"] = member.Type.Key
+
+	raw := s.function(args, "keys ...$.keyType|raw$", `	for _, key := range keys {
+		delete(o.$.memberAccessor$, key)
+	}
+`)
+	return named("SetterForMapKeyRemove", raw, args)
+}
+
+// GenerateWithLabelSelectorMatch generates a function setting a label on both the selector and the template labels.
+func (s *Setter) GenerateWithLabelSelectorMatch(match LabelSelectorMatch) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = "WithLabelSelectorMatch"
+
+	names := []string{}
+	if s.Root != s.Parent {
+		names = append(names, s.Parent.Name.Name)
+	}
+	for _, m := range match.Path {
+		names = append(names, m.Name)
+	}
+	prefix := strings.Join(append(names, ""), ".")
+
+	var body string
+	args["selector"] = prefix + match.Selector.Name
+	args["selectorLabels"] = args["selector"]
+	selectorType := match.Selector.Type
+	if selectorType.Kind == types.Pointer {
+		args["selectorType"] = selectorType.Elem
+		body += `	if o.$.selector$ == nil {
+		o.$.selector$ = &$.selectorType|raw${}
+	}
+`
+		selectorType = selectorType.Elem
+	}
+	if selectorType.Kind == types.Struct {
+		args["selectorLabels"] = prefix + match.Selector.Name + ".MatchLabels"
+	}
+	body += putLabel("selectorLabels")
+
+	args["template"] = prefix + match.Template.Name
+	args["templateLabels"] = prefix + match.Template.Name + ".ObjectMeta.Labels"
+	if match.Template.Type.Kind == types.Pointer {
+		args["templateType"] = match.Template.Type.Elem
+		body += `	if o.$.template$ == nil {
+		o.$.template$ = &$.templateType|raw${}
+	}
+`
+	}
+	body += putLabel("templateLabels")
+
+	raw := s.function(args, "key, value string", body)
+	return named("WithLabelSelectorMatch", raw, args)
+}
+
+func putLabel(accessor string) string {
+	return fmt.Sprintf(`	if o.$.%[1]s$ == nil {
+		o.$.%[1]s$ = map[string]string{}
+	}
+	o.$.%[1]s$[key] = value
+`, accessor)
+}
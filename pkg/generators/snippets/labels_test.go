@@ -0,0 +1,89 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+func TestGenerateSetterForMapKey(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta", "Labels")
+
+	want := `// WithLabel is an autogenerated function
+func (o *SomeStruct) WithLabel(key string, value string) *SomeStruct {
+	if o.ObjectMeta.Labels == nil {
+		o.ObjectMeta.Labels = make(map[string]string)
+	}
+	o.ObjectMeta.Labels[key] = value
+	return o
+}
+
+// RemoveLabel is an autogenerated function
+func (o *SomeStruct) RemoveLabel(keys ...string) *SomeStruct {
+	for _, key := range keys {
+		delete(o.ObjectMeta.Labels, key)
+	}
+	return o
+}
+
+`
+	var b bytes.Buffer
+	setter := NewSetter(someStruct, parent, true)
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(setter.GenerateSetterForMapKey(member, "Label"))
+	sw.Do(setter.GenerateSetterForMapKeyRemove(member, "Label"))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestGenerateWithLabelSelectorMatch(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	selectorStruct := newTestType(t, "SelectorStruct")
+	parent := getMemberFromType(t, selectorStruct, "SelectorStruct").Type
+
+	match := LabelSelectorMatch{
+		Path:     []types.Member{getMemberFromType(t, selectorStruct, "SelectorStruct", "Spec")},
+		Selector: getMemberFromType(t, selectorStruct, "SelectorStruct", "Spec", "Selector"),
+		Template: getMemberFromType(t, selectorStruct, "SelectorStruct", "Spec", "Template"),
+	}
+
+	want := `// WithLabelSelectorMatch is an autogenerated function
+func (o *SelectorStruct) WithLabelSelectorMatch(key, value string) *SelectorStruct {
+	o = o.DeepCopy()
+	if o.SelectorStruct.Spec.Selector == nil {
+		o.SelectorStruct.Spec.Selector = map[string]string{}
+	}
+	o.SelectorStruct.Spec.Selector[key] = value
+	if o.SelectorStruct.Spec.Template == nil {
+		o.SelectorStruct.Spec.Template = &a.TemplateSpec{}
+	}
+	if o.SelectorStruct.Spec.Template.ObjectMeta.Labels == nil {
+		o.SelectorStruct.Spec.Template.ObjectMeta.Labels = map[string]string{}
+	}
+	o.SelectorStruct.Spec.Template.ObjectMeta.Labels[key] = value
+	return o
+}
+
+`
+	var b bytes.Buffer
+	setter := NewSetter(selectorStruct, parent, true, WithCopyOnWrite())
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(setter.GenerateWithLabelSelectorMatch(match))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
@@ -1,6 +1,8 @@
 package snippets
 
-func GenerateMergeMapStringString() string {
+import "k8s.io/gengo/generator"
+
+func GenerateMergeMapStringString() (string, generator.Args) {
 	raw := `// mergeMapStringString creates a new map and loads it from map args
 // This function takes at least 2 args. Later map args take precedence.
 func mergeMapStringString(m1 map[string]string, mapArgs ...map[string]string) map[string]string {
@@ -18,5 +20,5 @@ func mergeMapStringString(m1 map[string]string, mapArgs ...map[string]string) ma
 }
 
 `
-	return raw
+	return named("MergeMapStringString", raw, nil)
 }
@@ -44,7 +44,7 @@ func mergeMapStringString(m1 map[string]string, mapArgs ...map[string]string) ma
 	for _, test := range tests {
 		var b bytes.Buffer
 		sw := generator.NewSnippetWriter(&b, test.ctx, "$", "$")
-		sw.Do(GenerateMergeMapStringString(), nil)
+		sw.Do(GenerateMergeMapStringString())
 		assert.NoError(t, sw.Error())
 		assert.Equal(t, test.want, b.String())
 
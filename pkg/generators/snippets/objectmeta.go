@@ -0,0 +1,84 @@
+package snippets
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// GenerateAddFinalizer generates a function adding a finalizer to the Finalizers member unless it is present.
+func (s *Setter) GenerateAddFinalizer(member types.Member) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = "AddFinalizer"
+	args["memberAccessor"] = s.memberAccessor(member)
+
+	raw := s.function(args, "finalizer string", `	if !containsString(o.$.memberAccessor$, finalizer) {
+		o.$.memberAccessor$ = append(o.$.memberAccessor$, finalizer)
+	}
+`)
+	return named("AddFinalizer", raw, args)
+}
+
+// GenerateRemoveFinalizer generates a function removing every occurrence of a finalizer from the Finalizers member.
+func (s *Setter) GenerateRemoveFinalizer(member types.Member) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = "RemoveFinalizer"
+	args["memberAccessor"] = s.memberAccessor(member)
+
+	raw := s.function(args, "finalizer string", `	var kept []string
+	for _, f := range o.$.memberAccessor$ {
+		if f != finalizer {
+			kept = append(kept, f)
+		}
+	}
+	o.$.memberAccessor$ = kept
+`)
+	return named("RemoveFinalizer", raw, args)
+}
+
+// GenerateHasFinalizer generates a function checking the Finalizers member for a finalizer.
+func (s *Setter) GenerateHasFinalizer(member types.Member) (string, generator.Args) {
+	args := s.args()
+	args["memberAccessor"] = s.memberAccessor(member)
+
+	raw := `// HasFinalizer is an autogenerated function
+func (o $.pointer$$.type|raw$) HasFinalizer(finalizer string) bool {
+	return containsString(o.$.memberAccessor$, finalizer)
+}
+
+`
+	return named("HasFinalizer", raw, args)
+}
+
+// GenerateWithOwner generates a function adding an owner reference, replacing an existing reference with the same UID.
+// The APIVersion and Kind are read from the TypeMeta of the owner when it is a runtime.Object, they stay empty for typed
+// client-go objects returned without TypeMeta, which Validate reports.
+// The object and owner reference types are looked up in the package of the parent type, e.g. k8s.io/apimachinery/pkg/apis/meta/v1.
+func (s *Setter) GenerateWithOwner(member types.Member) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = "WithOwner"
+	args["memberAccessor"] = s.memberAccessor(member)
+	args["object"] = types.Ref(s.Parent.Name.Package, "Object")
+	args["ownerReference"] = member.Type.Elem
+	args["runtimeObject"] = types.Ref(runtimePackage, "Object")
+
+	raw := s.function(args, "owner $.object|raw$, controller bool", `	ref := $.ownerReference|raw${Name: owner.GetName(), UID: owner.GetUID()}
+	if obj, ok := owner.($.runtimeObject|raw$); ok {
+		ref.APIVersion, ref.Kind = obj.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	}
+	if controller {
+		ref.Controller = &controller
+		ref.BlockOwnerDeletion = &controller
+	}
+	replaced := false
+	for i := range o.$.memberAccessor$ {
+		if o.$.memberAccessor$[i].UID == ref.UID {
+			o.$.memberAccessor$[i] = ref
+			replaced = true
+		}
+	}
+	if !replaced {
+		o.$.memberAccessor$ = append(o.$.memberAccessor$, ref)
+	}
+`)
+	return named("WithOwner", raw, args)
+}
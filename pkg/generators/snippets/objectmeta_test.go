@@ -0,0 +1,100 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+)
+
+func TestGenerateFinalizerHelpers(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta", "Finalizers")
+
+	want := `// AddFinalizer is an autogenerated function
+func (o *SomeStruct) AddFinalizer(finalizer string) *SomeStruct {
+	o = o.DeepCopy()
+	if !containsString(o.ObjectMeta.Finalizers, finalizer) {
+		o.ObjectMeta.Finalizers = append(o.ObjectMeta.Finalizers, finalizer)
+	}
+	return o
+}
+
+// RemoveFinalizer is an autogenerated function
+func (o *SomeStruct) RemoveFinalizer(finalizer string) *SomeStruct {
+	o = o.DeepCopy()
+	var kept []string
+	for _, f := range o.ObjectMeta.Finalizers {
+		if f != finalizer {
+			kept = append(kept, f)
+		}
+	}
+	o.ObjectMeta.Finalizers = kept
+	return o
+}
+
+// HasFinalizer is an autogenerated function
+func (o *SomeStruct) HasFinalizer(finalizer string) bool {
+	return containsString(o.ObjectMeta.Finalizers, finalizer)
+}
+
+`
+	var b bytes.Buffer
+	setter := NewSetter(someStruct, parent, true, WithCopyOnWrite())
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(setter.GenerateAddFinalizer(member))
+	sw.Do(setter.GenerateRemoveFinalizer(member))
+	sw.Do(setter.GenerateHasFinalizer(member))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestGenerateWithOwner(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta", "OwnerReferences")
+
+	want := `// WithOwner is an autogenerated function
+func (o *SomeStruct) WithOwner(owner b.Object, controller bool) *SomeStruct {
+	ref := b.OwnerReference{Name: owner.GetName(), UID: owner.GetUID()}
+	if obj, ok := owner.(runtime.Object); ok {
+		ref.APIVersion, ref.Kind = obj.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	}
+	if controller {
+		ref.Controller = &controller
+		ref.BlockOwnerDeletion = &controller
+	}
+	replaced := false
+	for i := range o.ObjectMeta.OwnerReferences {
+		if o.ObjectMeta.OwnerReferences[i].UID == ref.UID {
+			o.ObjectMeta.OwnerReferences[i] = ref
+			replaced = true
+		}
+	}
+	if !replaced {
+		o.ObjectMeta.OwnerReferences = append(o.ObjectMeta.OwnerReferences, ref)
+	}
+	return o
+}
+
+`
+	var b bytes.Buffer
+	setter := NewSetter(someStruct, parent, true)
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(setter.GenerateWithOwner(member))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
@@ -0,0 +1,108 @@
+package snippets
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+const yamlPackage = "sigs.k8s.io/yaml"
+
+// GenerateRender generates ToJSON and ToYAML rendering the parent object as a manifest, based on ToUnstructured.
+func GenerateRender(t *types.Type) (string, generator.Args) {
+	args := generator.Args{
+		"type":       t,
+		"jsonToYAML": types.Ref(yamlPackage, "JSONToYAML"),
+	}
+
+	raw := `// ToJSON is an autogenerated function
+func (o *$.type|raw$) ToJSON() ([]byte, error) {
+	content, err := o.ToUnstructured()
+	if err != nil {
+		return nil, err
+	}
+	return renderJSON(content)
+}
+
+// ToYAML is an autogenerated function
+func (o *$.type|raw$) ToYAML() ([]byte, error) {
+	data, err := o.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	return $.jsonToYAML|raw$(data)
+}
+
+`
+	return named("Render", raw, args)
+}
+
+// GenerateRenderHelpers generates renderJSON, which strips a status of only empty maps and lists and null creationTimestamps, and RenderAll.
+func GenerateRenderHelpers() (string, generator.Args) {
+	raw := `// renderJSON marshals unstructured content without an empty status and a null creationTimestamp in any metadata.
+func renderJSON(content map[string]interface{}) ([]byte, error) {
+	stripCreationTimestamp(content)
+	if isEmptyStatus(content["status"]) {
+		delete(content, "status")
+	}
+	return json.Marshal(content)
+}
+
+// isEmptyStatus returns true for nil and for maps and slices holding only empty values, e.g. the status {loadBalancer: {}} of a new Service.
+func isEmptyStatus(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		for _, nested := range v {
+			if !isEmptyStatus(nested) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		for _, nested := range v {
+			if !isEmptyStatus(nested) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// stripCreationTimestamp removes a null creationTimestamp from the metadata of the content and of every nested object, e.g. a pod template.
+func stripCreationTimestamp(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if metadata, ok := v["metadata"].(map[string]interface{}); ok && metadata["creationTimestamp"] == nil {
+			delete(metadata, "creationTimestamp")
+		}
+		for _, nested := range v {
+			stripCreationTimestamp(nested)
+		}
+	case []interface{}:
+		for _, nested := range v {
+			stripCreationTimestamp(nested)
+		}
+	}
+}
+
+// RenderAll renders the objects to a multi-document YAML stream.
+func RenderAll(objs ...interface{ ToYAML() ([]byte, error) }) ([]byte, error) {
+	var out bytes.Buffer
+	for i, obj := range objs {
+		data, err := obj.ToYAML()
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(data)
+	}
+	return out.Bytes(), nil
+}
+
+`
+	return named("RenderHelpers", raw, nil)
+}
@@ -0,0 +1,78 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+)
+
+func TestGenerateRender(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	want := `// ToJSON is an autogenerated function
+func (o *SomeStruct) ToJSON() ([]byte, error) {
+	content, err := o.ToUnstructured()
+	if err != nil {
+		return nil, err
+	}
+	return renderJSON(content)
+}
+
+// ToYAML is an autogenerated function
+func (o *SomeStruct) ToYAML() ([]byte, error) {
+	data, err := o.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	return yaml.JSONToYAML(data)
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateRender(newTestType(t, "SomeStruct")))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestGenerateRenderHelpers(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateRenderHelpers())
+	assert.NoError(t, sw.Error())
+	assert.Contains(t, b.String(), "\tstripCreationTimestamp(content)\n")
+	// nested metadata, e.g. of a pod template, is stripped as well
+	assert.Contains(t, b.String(), `		if metadata, ok := v["metadata"].(map[string]interface{}); ok && metadata["creationTimestamp"] == nil {
+			delete(metadata, "creationTimestamp")
+		}
+		for _, nested := range v {
+			stripCreationTimestamp(nested)
+		}`)
+	assert.Contains(t, b.String(), `	case []interface{}:
+		for _, nested := range v {
+			stripCreationTimestamp(nested)
+		}`)
+	// a status of empty nested maps and lists, e.g. of a Service, is stripped as well
+	assert.Contains(t, b.String(), `	if isEmptyStatus(content["status"]) {
+		delete(content, "status")
+	}`)
+	assert.Contains(t, b.String(), `	case map[string]interface{}:
+		for _, nested := range v {
+			if !isEmptyStatus(nested) {
+				return false
+			}
+		}
+		return true`)
+	assert.Contains(t, b.String(), `			out.WriteString("---\n")`)
+}
@@ -2,7 +2,9 @@ package snippets
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
 	"k8s.io/gengo/generator"
 	"k8s.io/gengo/types"
 )
@@ -11,121 +13,203 @@ type Setter struct {
 	Root            *types.Type
 	Parent          *types.Type
 	pointerReceiver bool
+	copyOnWrite     bool
+	replace         bool
+	options         bool
+	optionName      func(funcName string) string
 }
 
-func NewSetter(root, parent *types.Type, pointerReceiver bool) *Setter {
-	return &Setter{
+func NewSetter(root, parent *types.Type, pointerReceiver bool, opts ...func(s *Setter)) *Setter {
+	s := &Setter{
 		Root:            root,
 		Parent:          parent,
 		pointerReceiver: pointerReceiver,
 	}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// WithCopyOnWrite makes every setter operate on a deep copy of the receiver, leaving the receiver unchanged.
+// The root type must provide a DeepCopy method.
+func WithCopyOnWrite() func(s *Setter) {
+	return func(s *Setter) {
+		s.copyOnWrite = true
+	}
+}
+
+// WithOptionsStyle generates functional options instead of setter methods. optionName returns the name of the option
+// constructor of a setter, e.g. WithReplicas, which must be unique in the package of the root type.
+// Options are applied by the constructor, so the setters never copy the receiver.
+func WithOptionsStyle(optionName func(funcName string) string) func(s *Setter) {
+	return func(s *Setter) {
+		s.options = true
+		s.optionName = optionName
+	}
 }
 
 func (s *Setter) GenerateSetterForType(member types.Member) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["memberType"] = member.Type
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in $.memberType|raw$) $.pointer$$.type|raw$ {
-	o.$.memberAccessor$ = in
-	return o
-}
-
-`
-	return raw, args
+	raw := s.function(args, "in $.memberType|raw$", `	o.$.memberAccessor$ = in
+`)
+	return named("SetterForType", raw, args)
 }
 
 func (s *Setter) GenerateSetterForTypeEnum(member types.Member, argType *types.Type) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["argType"] = argType
 	args["enumType"] = member.Type
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in $.argType|raw$) $.pointer$$.type|raw$ {
-	o.$.memberAccessor$ = $.enumType|raw$(in)
-	return o
-}
-
-`
-	return raw, args
+	raw := s.function(args, "in $.argType|raw$", `	o.$.memberAccessor$ = $.enumType|raw$(in)
+`)
+	return named("SetterForTypeEnum", raw, args)
 }
 
 func (s *Setter) GenerateSetterForBool(member types.Member) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["memberType"] = member.Type
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in ...$.memberType|raw$) $.pointer$$.type|raw$ {
-	o.$.memberAccessor$ = variadicBool(in...)
-	return o
-}
-
-`
-	return raw, args
+	raw := s.function(args, "in ...$.memberType|raw$", `	o.$.memberAccessor$ = variadicBool(in...)
+`)
+	return named("SetterForBool", raw, args)
 }
 
 func (s *Setter) GenerateSetterForPointerToBool(member types.Member) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["memberType"] = member.Type.Elem
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in ...$.memberType|raw$) $.pointer$$.type|raw$ {
-	o.$.memberAccessor$ = boolPointer(variadicBool(in...))
-	return o
-}
-
-`
-	return raw, args
+	raw := s.function(args, "in ...$.memberType|raw$", `	o.$.memberAccessor$ = boolPointer(variadicBool(in...))
+`)
+	return named("SetterForPointerToBool", raw, args)
 }
 
 func (s *Setter) GenerateSetterForMap(member types.Member) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["memberType"] = member.Type
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in $.memberType|raw$) $.pointer$$.type|raw$ {
-	if o.$.memberAccessor$ == nil {
+	raw := s.function(args, "in $.memberType|raw$", `	if o.$.memberAccessor$ == nil {
 		o.$.memberAccessor$ = make($.memberType|raw$)
 	}
 	for key, value := range in {
 		o.$.memberAccessor$[key] = value
 	}
-	return o
+`)
+	return named("SetterForMap", raw, args)
 }
 
-`
-	return raw, args
+// GenerateSetterForEmbeddedMap generates a setter for a single key of a map whose values are an indexed struct or pointer to struct.
+func (s *Setter) GenerateSetterForEmbeddedMap(member types.Member, inputType *types.Type) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = s.funcName(member)
+	args["memberAccessor"] = s.memberAccessor(member)
+	args["memberType"] = member.Type
+	args["keyType"] = member.Type.Key
+	args["inputType"] = inputType
+	args["reference"] = ""
+	args["structType"] = member.Type.Elem.Name.Name
+	if member.Type.Elem.Kind == types.Pointer {
+		args["reference"] = "&"
+		args["structType"] = member.Type.Elem.Elem.Name.Name
+	}
+
+	raw := s.function(args, "key $.keyType|raw$, in *$.inputType|raw$", `	if in != nil {
+		if o.$.memberAccessor$ == nil {
+			o.$.memberAccessor$ = make($.memberType|raw$)
+		}
+		o.$.memberAccessor$[key] = $.reference$in.$.structType$
+	}
+`)
+	return named("SetterForEmbeddedMap", raw, args)
 }
 
-func (s *Setter) GenerateSetterForMapStringString(member types.Member) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+// GenerateSetterForMapPut generates a setter for a single key of a map.
+func (s *Setter) GenerateSetterForMapPut(member types.Member) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = fmt.Sprintf("Put%s", memberFuncName(member))
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["memberType"] = member.Type
+	args["keyType"] = member.Type.Key
+	args["elemType"] = member.Type.Elem
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in $.memberType|raw$) $.pointer$$.type|raw$ {
-	o.$.memberAccessor$ = mergeMapStringString(o.$.memberAccessor$, in)
-	return o
+	raw := s.function(args, "key $.keyType|raw$, value $.elemType|raw$", `	if o.$.memberAccessor$ == nil {
+		o.$.memberAccessor$ = make($.memberType|raw$)
+	}
+	o.$.memberAccessor$[key] = value
+`)
+	return named("SetterForMapPut", raw, args)
 }
 
-`
-	return raw, args
+// GenerateSetterForMapDelete generates a function removing keys from a map.
+func (s *Setter) GenerateSetterForMapDelete(member types.Member) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = fmt.Sprintf("Delete%s", memberFuncName(member))
+	args["memberAccessor"] = s.memberAccessor(member)
+	args["keyType"] = member.Type.Key
+
+	raw := s.function(args, "keys ...$.keyType|raw$", `	for _, key := range keys {
+		delete(o.$.memberAccessor$, key)
+	}
+`)
+	return named("SetterForMapDelete", raw, args)
+}
+
+// GenerateSetterForSliceClear generates a function removing all elements of a slice.
+func (s *Setter) GenerateSetterForSliceClear(member types.Member) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = fmt.Sprintf("Clear%s", memberFuncName(member))
+	args["memberAccessor"] = s.memberAccessor(member)
+
+	raw := s.function(args, "", `	o.$.memberAccessor$ = nil
+`)
+	return named("SetterForSliceClear", raw, args)
+}
+
+// GenerateSetterForSliceRemove generates a function removing the elements of a slice matching a predicate.
+func (s *Setter) GenerateSetterForSliceRemove(member types.Member) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = fmt.Sprintf("Remove%s", memberFuncName(member))
+	args["memberAccessor"] = s.memberAccessor(member)
+	args["memberType"] = member.Type
+	args["elemType"] = member.Type.Elem
+
+	raw := s.function(args, "predicate func($.elemType|raw$) bool", `	var kept $.memberType|raw$
+	for _, elem := range o.$.memberAccessor$ {
+		if !predicate(elem) {
+			kept = append(kept, elem)
+		}
+	}
+	o.$.memberAccessor$ = kept
+`)
+	return named("SetterForSliceRemove", raw, args)
+}
+
+func (s *Setter) GenerateSetterForMapStringString(member types.Member) (string, generator.Args) {
+	args := s.args()
+	args["funcName"] = s.funcName(member)
+	args["memberAccessor"] = s.memberAccessor(member)
+	args["memberType"] = member.Type
+
+	raw := s.function(args, "in $.memberType|raw$", `	o.$.memberAccessor$ = mergeMapStringString(o.$.memberAccessor$, in)
+`)
+	return named("SetterForMapStringString", raw, args)
 }
 
 func (s *Setter) GenerateSetterForMemberSlice(member types.Member) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 
 	var raw string
@@ -133,177 +217,186 @@ func (s *Setter) GenerateSetterForMemberSlice(member types.Member) (string, gene
 	switch member.Type.Elem {
 	case types.Byte:
 		args["memberType"] = member.Type
-		raw = `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in $.memberType|raw$) $.pointer$$.type|raw$ {
-	o.$.memberAccessor$ = in
-	return o
-}
-
-`
+		raw = s.function(args, "in $.memberType|raw$", `	o.$.memberAccessor$ = in
+`)
 	default:
 		args["memberType"] = member.Type.Elem
-		raw = `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in ...$.memberType|raw$) $.pointer$$.type|raw$ {
-	o.$.memberAccessor$ = append(o.$.memberAccessor$, in...)
-	return o
-}
-
-`
+		raw = s.function(args, "in ...$.memberType|raw$", `	o.$.memberAccessor$ = append(o.$.memberAccessor$, in...)
+`)
 	}
 
-	return raw, args
+	return named("SetterForMemberSlice", raw, args)
 }
 
 func (s *Setter) GenerateSetterForEmbeddedSlice(member types.Member, argType *types.Type) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["inputType"] = argType
 	args["sliceType"] = member.Type.Elem.Name.Name
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in ...*$.inputType|raw$) $.pointer$$.type|raw$ {
-	for _, elem := range in {
+	raw := s.function(args, "in ...*$.inputType|raw$", `	for _, elem := range in {
 		if elem != nil {
 			o.$.memberAccessor$ = append(o.$.memberAccessor$, elem.$.sliceType$)
 		}
 	}
-	return o
-}
-
-`
-	return raw, args
+`)
+	return named("SetterForEmbeddedSlice", raw, args)
 }
 
 func (s *Setter) GenerateSetterForEmbeddedSliceEnum(member types.Member, argType *types.Type) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["argType"] = argType
 	args["enumType"] = member.Type
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in ...$.argType|raw$) $.pointer$$.type|raw$ {
-	for _, elem := range in {
+	raw := s.function(args, "in ...$.argType|raw$", `	for _, elem := range in {
 		o.$.memberAccessor$ = append(o.$.memberAccessor$,  $slice (.enumType|raw) 2$(elem))
 	}
-	return o
-}
-
-`
-	return raw, args
+`)
+	return named("SetterForEmbeddedSliceEnum", raw, args)
 }
 
 func (s *Setter) GenerateSetterForEmbeddedSlicePointer(member types.Member, argType *types.Type) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["inputType"] = argType
-	args["sliceType"] = argType.Name.Name
+	args["sliceType"] = member.Type.Elem.Elem.Name.Name
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in ...*$.inputType|raw$) $.pointer$$.type|raw$ {
-	for _, elem := range in {
+	raw := s.function(args, "in ...*$.inputType|raw$", `	for _, elem := range in {
 		if elem != nil {
 			o.$.memberAccessor$ = append(o.$.memberAccessor$, &elem.$.sliceType$)
 		}
 	}
-	return o
-}
-
-`
-	return raw, args
+`)
+	return named("SetterForEmbeddedSlicePointer", raw, args)
 }
 
 func (s *Setter) GenerateSetterForMemberStruct(member types.Member) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["memberType"] = member.Type
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in *$.memberType|raw$) $.pointer$$.type|raw$ {
-	if in != nil {
+	raw := s.function(args, "in *$.memberType|raw$", `	if in != nil {
 		o.$.memberAccessor$ = *in
 	}
-	return o
-}
-
-`
-	return raw, args
+`)
+	return named("SetterForMemberStruct", raw, args)
 }
 
 func (s *Setter) GenerateSetterForEmbeddedStruct(member types.Member, inputType *types.Type) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["inputType"] = inputType
 	args["structType"] = member.Type.Name.Name
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in *$.inputType|raw$) $.pointer$$.type|raw$ {
-	if in != nil {
+	raw := s.function(args, "in *$.inputType|raw$", `	if in != nil {
 		o.$.memberAccessor$ = in.$.structType$
 	}
-	return o
-}
-
-`
-	return raw, args
+`)
+	return named("SetterForEmbeddedStruct", raw, args)
 }
 
 func (s *Setter) GenerateSetterForPointerToBuiltinType(member types.Member) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["memberElemType"] = member.Type.Elem
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in $.memberElemType|raw$) $.pointer$$.type|raw$ {
-	o.$.memberAccessor$ = &in
-	return o
-}
+	raw := s.function(args, "in $.memberElemType|raw$", `	o.$.memberAccessor$ = &in
+`)
 
-`
-
-	return raw, args
+	return named("SetterForPointerToBuiltinType", raw, args)
 }
 
 func (s *Setter) GenerateSetterForEmbeddedPointer(member types.Member, inputType *types.Type) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = s.memberAccessor(member)
 	args["inputType"] = inputType
 	args["structType"] = member.Type.Elem.Name.Name
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in *$.inputType|raw$) $.pointer$$.type|raw$ {
-	if in != nil {
+	raw := s.function(args, "in *$.inputType|raw$", `	if in != nil {
 		o.$.memberAccessor$ = &in.$.structType$
 	}
-	return o
-}
-
-`
-	return raw, args
+`)
+	return named("SetterForEmbeddedPointer", raw, args)
 }
 
 func (s *Setter) GenerateSetterForAliasPointerPrimitive(member types.Member, inputType *types.Type) (string, generator.Args) {
-	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
-	args["funcName"] = funcName(member)
+	args := s.args()
+	args["funcName"] = s.funcName(member)
 	args["memberAccessor"] = member.Name
 	args["inputType"] = member.Type
 	args["argType"] = inputType
 
-	raw := `// $.funcName$ is an autogenerated function
-func (o $.pointer$$.type|raw$) $.funcName$(in $.argType|raw$) $.pointer$$.type|raw$ {
-	p := $ slice (.inputType|raw) 1$(in)
+	raw := s.function(args, "in $.argType|raw$", `	p := $ slice (.inputType|raw) 1$(in)
 	o.$.memberAccessor$ = &p
-	return o
+`)
+	return named("SetterForAliasPointerPrimitive", raw, args)
+}
+
+// Replacing returns a copy of the setter whose slice setters replace the existing elements instead of appending to them.
+func (s *Setter) Replacing() *Setter {
+	r := *s
+	r.replace = true
+	return &r
+}
+
+// args returns the arguments shared by every setter. The setter options are provided for template overrides.
+func (s *Setter) args() generator.Args {
+	args := defaultGeneratorArgs(s.Root, s.pointerReceiver)
+	args["copyOnWrite"] = s.copyOnWrite
+	args["replace"] = s.replace
+	args["options"] = s.options
+	return args
+}
+
+// function wraps the body of a setter named by the funcName argument in a function accepting params and returning the receiver.
+func (s *Setter) function(args generator.Args, params string, body string) string {
+	if s.replace {
+		body = "\to.$.memberAccessor$ = nil\n" + body
+	}
+
+	if s.options {
+		args["optionName"] = s.optionName(args["funcName"].(string))
+		return optionFunction(params, body)
+	}
+
+	if s.copyOnWrite {
+		body = "\to = o.DeepCopy()\n" + body
+	}
+
+	return `// $.funcName$ is an autogenerated function
+func (o $.pointer$$.type|raw$) $.funcName$(` + params + `) $.pointer$$.type|raw$ {
+` + body + `	return o
+}
+
+`
+}
+
+// optionFunction wraps the body of a setter in a functional option of the root type named by the optionName argument and accepting params.
+func optionFunction(params string, body string) string {
+	indented := strings.ReplaceAll(strings.TrimSuffix(body, "\n"), "\n", "\n\t")
+	return `// $.optionName$ is an autogenerated option
+func $.optionName$(` + params + `) $.type|raw$Option {
+	return func(o *$.type|raw$) {
+	` + indented + `
+	}
 }
 
 `
-	return raw, args
+}
+
+func (s *Setter) funcName(m types.Member) string {
+	if s.replace {
+		return fmt.Sprintf("Set%s", memberFuncName(m))
+	}
+	return funcName(m)
 }
 
 func funcName(m types.Member) string {
@@ -313,12 +406,24 @@ func funcName(m types.Member) string {
 		verb = "Append"
 	}
 
-	return fmt.Sprintf("%s%s", verb, m.Name)
+	return fmt.Sprintf("%s%s", verb, memberFuncName(m))
+}
+
+// memberFuncName returns the name of the member used in generated function names.
+func memberFuncName(m types.Member) string {
+	if name := tags.ExtractMemberName(m); name != "" {
+		return name
+	}
+	return m.Name
 }
 
 func (s *Setter) memberAccessor(member types.Member) string {
-	if s.Root != s.Parent {
-		return fmt.Sprintf("%s.%s", s.Parent.Name.Name, member.Name)
+	return memberAccessor(s.Root, s.Parent, member)
+}
+
+func memberAccessor(root, parent *types.Type, member types.Member) string {
+	if root != parent {
+		return fmt.Sprintf("%s.%s", parent.Name.Name, member.Name)
 	}
 	return member.Name
 }
@@ -60,6 +60,69 @@ func (o *SomeStruct) WithName(in string) *SomeStruct {
 	}
 }
 
+func TestGenerateSetterWithCopyOnWrite(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "MapIntString")
+
+	want := `// WithMapIntString is an autogenerated function
+func (o *SomeStruct) WithMapIntString(in map[int]string) *SomeStruct {
+	o = o.DeepCopy()
+	if o.SomeStruct.MapIntString == nil {
+		o.SomeStruct.MapIntString = make(map[int]string)
+	}
+	for key, value := range in {
+		o.SomeStruct.MapIntString[key] = value
+	}
+	return o
+}
+
+`
+	var b bytes.Buffer
+	setter := NewSetter(someStruct, parent, true, WithCopyOnWrite())
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(setter.GenerateSetterForMap(member))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestGenerateSetterWithOptionsStyle(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "MapIntString")
+
+	want := `// WithMapIntString is an autogenerated option
+func WithMapIntString(in map[int]string) SomeStructOption {
+	return func(o *SomeStruct) {
+		if o.SomeStruct.MapIntString == nil {
+			o.SomeStruct.MapIntString = make(map[int]string)
+		}
+		for key, value := range in {
+			o.SomeStruct.MapIntString[key] = value
+		}
+	}
+}
+
+`
+	var b bytes.Buffer
+	optionName := func(funcName string) string { return funcName }
+	setter := NewSetter(someStruct, parent, true, WithOptionsStyle(optionName), WithCopyOnWrite())
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(setter.GenerateSetterForMap(member))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
 func TestGenerateSetterForBool(t *testing.T) {
 	t.Parallel()
 
@@ -169,6 +232,102 @@ func (o *SomeStruct) WithMapStringByteSlice(in map[string][]byte) *SomeStruct {
 	}
 }
 
+func TestGenerateSetterForEmbeddedMap(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	cStruct := newTestType(t, "CStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+
+	tests := []struct {
+		description string
+		member      types.Member
+		want        string
+	}{
+		{
+			description: "map of structs",
+			member:      getMemberFromType(t, someStruct, "SomeStruct", "MapStringCStruct"),
+			want: `// WithMapStringCStruct is an autogenerated function
+func (o *SomeStruct) WithMapStringCStruct(key string, in *CStruct) *SomeStruct {
+	if in != nil {
+		if o.SomeStruct.MapStringCStruct == nil {
+			o.SomeStruct.MapStringCStruct = make(map[string]a.CStruct)
+		}
+		o.SomeStruct.MapStringCStruct[key] = in.CStruct
+	}
+	return o
+}
+
+`,
+		},
+		{
+			description: "map of pointers to structs",
+			member:      getMemberFromType(t, someStruct, "SomeStruct", "MapIntPointerCStruct"),
+			want: `// WithMapIntPointerCStruct is an autogenerated function
+func (o *SomeStruct) WithMapIntPointerCStruct(key int, in *CStruct) *SomeStruct {
+	if in != nil {
+		if o.SomeStruct.MapIntPointerCStruct == nil {
+			o.SomeStruct.MapIntPointerCStruct = make(map[int]*a.CStruct)
+		}
+		o.SomeStruct.MapIntPointerCStruct[key] = &in.CStruct
+	}
+	return o
+}
+
+`,
+		},
+	}
+
+	for _, test := range tests {
+		var b bytes.Buffer
+		setter := NewSetter(someStruct, parent, true)
+		sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+		sw.Do(setter.GenerateSetterForEmbeddedMap(test.member, cStruct))
+		assert.NoError(t, sw.Error(), test.description)
+		assert.Equal(t, test.want, b.String(), test.description)
+	}
+}
+
+func TestGenerateSetterForMapPutAndDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "MapIntString")
+
+	want := `// PutMapIntString is an autogenerated function
+func (o *SomeStruct) PutMapIntString(key int, value string) *SomeStruct {
+	if o.SomeStruct.MapIntString == nil {
+		o.SomeStruct.MapIntString = make(map[int]string)
+	}
+	o.SomeStruct.MapIntString[key] = value
+	return o
+}
+
+// DeleteMapIntString is an autogenerated function
+func (o *SomeStruct) DeleteMapIntString(keys ...int) *SomeStruct {
+	for _, key := range keys {
+		delete(o.SomeStruct.MapIntString, key)
+	}
+	return o
+}
+
+`
+	var b bytes.Buffer
+	setter := NewSetter(someStruct, parent, true)
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(setter.GenerateSetterForMapPut(member))
+	sw.Do(setter.GenerateSetterForMapDelete(member))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
 func TestGenerateSetterForMapStringString(t *testing.T) {
 	t.Parallel()
 
@@ -245,6 +404,57 @@ func (o *SomeStruct) WithBytes(in []byte) *SomeStruct {
 	}
 }
 
+func TestGenerateSetterForSliceReplaceClearRemove(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	cStruct := newTestType(t, "CStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "CStructs")
+
+	want := `// SetCStructs is an autogenerated function
+func (o *SomeStruct) SetCStructs(in ...*CStruct) *SomeStruct {
+	o.SomeStruct.CStructs = nil
+	for _, elem := range in {
+		if elem != nil {
+			o.SomeStruct.CStructs = append(o.SomeStruct.CStructs, elem.CStruct)
+		}
+	}
+	return o
+}
+
+// ClearCStructs is an autogenerated function
+func (o *SomeStruct) ClearCStructs() *SomeStruct {
+	o.SomeStruct.CStructs = nil
+	return o
+}
+
+// RemoveCStructs is an autogenerated function
+func (o *SomeStruct) RemoveCStructs(predicate func(a.CStruct) bool) *SomeStruct {
+	var kept []a.CStruct
+	for _, elem := range o.SomeStruct.CStructs {
+		if !predicate(elem) {
+			kept = append(kept, elem)
+		}
+	}
+	o.SomeStruct.CStructs = kept
+	return o
+}
+
+`
+	var b bytes.Buffer
+	setter := NewSetter(someStruct, parent, true)
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(setter.Replacing().GenerateSetterForEmbeddedSlice(member, cStruct))
+	sw.Do(setter.GenerateSetterForSliceClear(member))
+	sw.Do(setter.GenerateSetterForSliceRemove(member))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
 func TestGenerateSetterForEmbeddedSlice(t *testing.T) {
 	t.Parallel()
 
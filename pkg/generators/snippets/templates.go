@@ -0,0 +1,141 @@
+package snippets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/gengo/generator"
+)
+
+// TemplateExtension is the file extension of snippet template overrides.
+const TemplateExtension = ".tmpl"
+
+// TemplateNames lists every snippet that can be overridden, named after its Generate function.
+var TemplateNames = []string{
+	"AddFinalizer",
+	"ApplyConfiguration",
+	"ApplyConfigurationConstructor",
+	"ApplyObjectMeta",
+	"ApplyObjectMetaSetter",
+	"ApplySetter",
+	"ApplyToUnstructured",
+	"BoolPointer",
+	"Build",
+	"ConstructorForObjectMeta",
+	"ConstructorFrom",
+	"ConstructorInNamespace",
+	"ConstructorWithRequired",
+	"ContainsString",
+	"DeepCopy",
+	"DeepCopyObject",
+	"EmptyConstructor",
+	"EnumHelpers",
+	"EnumSetter",
+	"FromUnstructured",
+	"GetterForAliasPointerPrimitive",
+	"GetterForEmbeddedPointer",
+	"GetterForEmbeddedSlice",
+	"GetterForEmbeddedSliceEnum",
+	"GetterForEmbeddedSlicePointer",
+	"GetterForEmbeddedStruct",
+	"GetterForPointerToBuiltinType",
+	"GetterForType",
+	"GetterForTypeEnum",
+	"HasFinalizer",
+	"MergeMapStringString",
+	"OptionType",
+	"RemoveFinalizer",
+	"Render",
+	"RenderHelpers",
+	"SetterForAliasPointerPrimitive",
+	"SetterForBool",
+	"SetterForEmbeddedMap",
+	"SetterForEmbeddedPointer",
+	"SetterForEmbeddedSlice",
+	"SetterForEmbeddedSliceEnum",
+	"SetterForEmbeddedSlicePointer",
+	"SetterForEmbeddedStruct",
+	"SetterForMap",
+	"SetterForMapDelete",
+	"SetterForMapKey",
+	"SetterForMapKeyRemove",
+	"SetterForMapPut",
+	"SetterForMapStringString",
+	"SetterForMemberSlice",
+	"SetterForMemberStruct",
+	"SetterForPointerToBool",
+	"SetterForPointerToBuiltinType",
+	"SetterForSliceClear",
+	"SetterForSliceRemove",
+	"SetterForType",
+	"SetterForTypeEnum",
+	"ToUnstructured",
+	"Validate",
+	"VariadicBool",
+	"WithLabelSelectorMatch",
+	"WithOwner",
+	"WrapConstructor",
+	"WrapperType",
+}
+
+// templateArg is the template argument naming the snippet, so that Templates can replace its raw template.
+const templateArg = "snippetName"
+
+// Templates holds the user supplied templates by snippet name.
+type Templates map[string]string
+
+// LoadTemplates loads a template for every snippet that has a <name>.tmpl file in dir.
+// Templates are gengo templates using the "$" delimiters and the same arguments as the built-in snippet.
+func LoadTemplates(dir string) (Templates, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := Templates{}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != TemplateExtension {
+			continue
+		}
+
+		name := strings.TrimSuffix(e.Name(), TemplateExtension)
+		if !isTemplateName(name) {
+			return nil, fmt.Errorf("template %s does not match a snippet, must be one of: %s", e.Name(), strings.Join(TemplateNames, ", "))
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		templates[name] = string(content)
+	}
+
+	return templates, nil
+}
+
+// Apply replaces the raw template of a snippet with the user supplied template, if any.
+func (t Templates) Apply(raw string, args generator.Args) (string, generator.Args) {
+	if name, ok := args[templateArg].(string); ok {
+		if override, ok := t[name]; ok {
+			return override, args
+		}
+	}
+	return raw, args
+}
+
+// named returns the built-in raw template of a snippet along with its arguments, which name the snippet.
+func named(name string, raw string, args generator.Args) (string, generator.Args) {
+	if args == nil {
+		args = generator.Args{}
+	}
+	args[templateArg] = name
+	return raw, args
+}
+
+func isTemplateName(name string) bool {
+	i := sort.SearchStrings(TemplateNames, name)
+	return i < len(TemplateNames) && TemplateNames[i] == name
+}
@@ -0,0 +1,105 @@
+package snippets
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+)
+
+func TestTemplateNamesSorted(t *testing.T) {
+	assert.True(t, sort.StringsAreSorted(TemplateNames))
+}
+
+func TestLoadTemplates(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "EmptyConstructor.tmpl"), []byte(`// Create$.type|raw$ returns an empty builder.
+func Create$.type|raw$() *$.type|raw$ {
+	return &$.type|raw${}
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "SetterForType.tmpl"), []byte(`func (b *$.type|raw$) $.funcName$(in $.memberType|raw$) *$.type|raw$ {
+$- if .copyOnWrite$
+	b = b.DeepCopy()
+$- end$
+	b.$.memberAccessor$ = in
+	return b
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Build.tmpl"), []byte(`func (o *$.type|raw$) Build() *$.parent|raw$ {
+$- if .hasDeepCopy$
+	return o.$.parentName$.DeepCopy()
+$- else$
+	return &o.$.parentName$
+$- end$
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Validate.tmpl"), []byte(`func (o *$.type|raw$) Validate() error {
+	return nil
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored"), 0644))
+	templates, err := LoadTemplates(dir)
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "Bool")
+
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(templates.Apply(GenerateEmptyConstructor(someStruct, true, false)))
+	sw.Do(templates.Apply(NewSetter(someStruct, parent, true, WithCopyOnWrite()).GenerateSetterForType(member)))
+	sw.Do(templates.Apply(GenerateVariadicBool()))
+	sw.Do(templates.Apply(GenerateBuild(someStruct, parent, false)))
+	sw.Do(templates.Apply(NewValidator(someStruct).GenerateValidate()))
+	assert.NoError(t, sw.Error())
+
+	assert.Contains(t, b.String(), `// CreateSomeStruct returns an empty builder.
+func CreateSomeStruct() *SomeStruct {
+	return &SomeStruct{}
+}
+`)
+	assert.Contains(t, b.String(), `func (b *SomeStruct) WithBool(in bool) *SomeStruct {
+	b = b.DeepCopy()
+	b.SomeStruct.Bool = in
+	return b
+}
+`)
+	assert.Contains(t, b.String(), "func variadicBool(in ...bool) bool", "snippets without override use the built-in template")
+	assert.Contains(t, b.String(), `func (o *SomeStruct) Build() *a.SomeStruct {
+	return &o.SomeStruct
+}
+`, "templates receive the inputs of the built-in template")
+	assert.Contains(t, b.String(), `func (o *SomeStruct) Validate() error {
+	return nil
+}
+`)
+	assert.NotContains(t, b.String(), "autogenerated function\nfunc (o *SomeStruct) Validate() error")
+
+	b.Reset()
+	sw.Do(GenerateEmptyConstructor(someStruct, true, false))
+	assert.Contains(t, b.String(), "func NewSomeStruct() *SomeStruct", "snippets are only replaced by the templates they are applied to")
+}
+
+func TestLoadTemplatesErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadTemplates(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "NoSuchSnippet.tmpl"), []byte(""), 0644))
+	_, err = LoadTemplates(dir)
+	assert.ErrorContains(t, err, "NoSuchSnippet.tmpl does not match a snippet")
+}
@@ -7,21 +7,23 @@ import (
 type SomeStruct struct {
 	b.TypeMeta
 	b.ObjectMeta
-	AStruct            AStruct
-	CStruct            CStruct
-	PointerCStruct     *CStruct
-	CStructs           []CStruct
-	Strings            []string
-	Bytes              []byte
-	IntPtr             *int
-	MapIntString       map[int]string
-	MapStringByteSlice map[string][]byte
-	Bool               bool
-	PointerBool        *bool
-	Alias              *b.AliasOfString
-	AnEnum             b.AliasOfString
-	ManyEnums          []b.AliasOfString
-	ManyPointers       []*CStruct
+	AStruct              AStruct
+	CStruct              CStruct
+	PointerCStruct       *CStruct
+	CStructs             []CStruct
+	Strings              []string
+	Bytes                []byte
+	IntPtr               *int
+	MapIntString         map[int]string
+	MapStringByteSlice   map[string][]byte
+	Bool                 bool
+	PointerBool          *bool
+	Alias                *b.AliasOfString
+	AnEnum               b.AliasOfString
+	ManyEnums            []b.AliasOfString
+	ManyPointers         []*CStruct
+	MapStringCStruct     map[string]CStruct
+	MapIntPointerCStruct map[int]*CStruct
 }
 
 type AStruct struct {
@@ -31,3 +33,65 @@ type AStruct struct {
 type CStruct struct {
 	Int int
 }
+
+func (in *CStruct) DeepCopy() *CStruct {
+	// not impl test only
+	return in
+}
+
+type ValidatedStruct struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	Replicas *int32 `json:"replicas,omitempty"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:Pattern=`^[a-z]+$`
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=Always;Never
+	Policy b.AliasOfString `json:"policy,omitempty"`
+	// +kubebuilder:validation:Required
+	Items []string `json:"items"`
+	// +kubebuilder:validation:Minimum=1
+	Port int32 `json:"port,omitempty"`
+	// +kubebuilder:validation:Pattern=`^[a-z`
+	Invalid string `json:"invalid,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas int32   `json:"minReplicas"`
+	Weight      uint8   `json:"weight,omitempty"`
+	Ratio       float32 `json:"ratio,omitempty"`
+}
+
+type SelectorStruct struct {
+	Spec SelectorSpec
+}
+
+type SelectorSpec struct {
+	Selector map[string]string
+	Template *TemplateSpec
+}
+
+type TemplateSpec struct {
+	b.ObjectMeta
+}
+
+type NestedStruct struct {
+	Spec NestedSpec `json:"spec"`
+}
+
+type NestedSpec struct {
+	Template  *NestedTemplate            `json:"template,omitempty"`
+	Templates map[string]*NestedTemplate `json:"templates,omitempty"`
+}
+
+type NestedTemplate struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// +kubebuilder:validation:Maximum=10
+	Replicas *int32       `json:"replicas,omitempty"`
+	Ports    []NestedPort `json:"ports,omitempty"`
+}
+
+type NestedPort struct {
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
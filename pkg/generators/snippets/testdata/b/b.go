@@ -8,6 +8,22 @@ type TypeMeta struct {
 type AliasOfString string
 
 type ObjectMeta struct {
-	Name   string
-	Labels map[string]string
+	Name            string
+	Labels          map[string]string
+	Finalizers      []string
+	OwnerReferences []OwnerReference
+}
+
+type OwnerReference struct {
+	APIVersion         string
+	Kind               string
+	Name               string
+	UID                string
+	Controller         *bool
+	BlockOwnerDeletion *bool
+}
+
+type Object interface {
+	GetName() string
+	GetUID() string
 }
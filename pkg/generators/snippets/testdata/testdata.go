@@ -29,3 +29,15 @@ type AnEnum b.AliasOfString
 func (c *ComplexStruct) DeepCopyInto(in *ComplexStruct) {
 	// not impl test only
 }
+
+type ValidatedStruct struct {
+	a.ValidatedStruct
+}
+
+type SelectorStruct struct {
+	a.SelectorStruct
+}
+
+type NestedStruct struct {
+	a.NestedStruct
+}
@@ -0,0 +1,44 @@
+package snippets
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// GenerateToUnstructured generates a conversion of the parent object to the content of an unstructured object.
+func GenerateToUnstructured(t *types.Type, parent *types.Type) (string, generator.Args) {
+	args := unstructuredArgs(t, parent)
+
+	raw := `// ToUnstructured is an autogenerated function
+func (o *$.type|raw$) ToUnstructured() (map[string]interface{}, error) {
+	return $.converter|raw$.ToUnstructured(&o.$.parentName$)
+}
+
+`
+	return named("ToUnstructured", raw, args)
+}
+
+// GenerateFromUnstructured generates a constructor converting the content of an unstructured object to the parent object.
+func GenerateFromUnstructured(t *types.Type, parent *types.Type) (string, generator.Args) {
+	args := unstructuredArgs(t, parent)
+
+	raw := `// $.type|raw$FromUnstructured is an autogenerated constructor.
+func $.type|raw$FromUnstructured(content map[string]interface{}) (*$.type|raw$, error) {
+	o := &$.type|raw${}
+	if err := $.converter|raw$.FromUnstructured(content, &o.$.parentName$); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+`
+	return named("FromUnstructured", raw, args)
+}
+
+func unstructuredArgs(t *types.Type, parent *types.Type) generator.Args {
+	return generator.Args{
+		"type":       t,
+		"parentName": parent.Name.Name,
+		"converter":  types.Ref(runtimePackage, "DefaultUnstructuredConverter"),
+	}
+}
@@ -0,0 +1,42 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+)
+
+func TestGenerateUnstructured(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+
+	want := `// ToUnstructured is an autogenerated function
+func (o *SomeStruct) ToUnstructured() (map[string]interface{}, error) {
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(&o.SomeStruct)
+}
+
+// SomeStructFromUnstructured is an autogenerated constructor.
+func SomeStructFromUnstructured(content map[string]interface{}) (*SomeStruct, error) {
+	o := &SomeStruct{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, &o.SomeStruct); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateToUnstructured(someStruct, parent))
+	sw.Do(GenerateFromUnstructured(someStruct, parent))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
@@ -0,0 +1,381 @@
+package snippets
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+const fieldPackage = "k8s.io/apimachinery/pkg/util/validation/field"
+
+type Validator struct {
+	Root     *types.Type
+	rules    []string
+	literals []string
+	patterns []string
+}
+
+func NewValidator(root *types.Type) *Validator {
+	return &Validator{
+		Root: root,
+	}
+}
+
+// AddMember records the validation rules of a member reachable from Root through parent and the struct members of via,
+// e.g. Spec for Spec.Replicas. The path is the list of serialized field names used to report violations.
+// A slice or string keyed map member of via is iterated and the next member is read from each element, which is
+// reported by index or key, e.g. spec.items[0].key. Rules of a member nested in a pointer are skipped while the pointer is nil.
+// Value rules are skipped while an optional member is unset, i.e. nil or, for a member marked +optional or
+// serialized with omitempty, the zero value.
+func (v *Validator) AddMember(parent *types.Type, via []types.Member, member types.Member, validation tags.Validation, path ...string) error {
+	if validation.Pattern != "" {
+		if _, err := regexp.Compile(validation.Pattern); err != nil {
+			return fmt.Errorf("member %s: invalid pattern %q: %w", member.Name, validation.Pattern, err)
+		}
+	}
+
+	for marker, bound := range map[string]string{tags.ValidationMinimum: validation.Minimum, tags.ValidationMaximum: validation.Maximum} {
+		if err := checkBound(member, marker, bound); err != nil {
+			return err
+		}
+	}
+
+	if err := checkMinLength(member, validation.MinLength); err != nil {
+		return err
+	}
+
+	chain := append(append([]types.Member{}, via...), member)
+	// path may start with names of members outside of the chain, e.g. metadata
+	offset := len(path) - len(chain)
+	names := []string{}
+	for i := 0; i <= offset; i++ {
+		names = append(names, v.literal(strconv.Quote(path[i])))
+	}
+
+	accessor, nilGuard, fieldPath := "o."+memberAccessor(v.Root, parent, chain[0]), "", ""
+	loops := []validationLoop{}
+	for i := 1; i < len(chain); i++ {
+		container := chain[i-1].Type
+		if container.Kind == types.Pointer {
+			nilGuard += accessor + " != nil && "
+			container = container.Elem
+		}
+
+		if container.Kind == types.Slice || container.Kind == types.Map {
+			index := fmt.Sprintf("i%d", len(loops))
+			element := ".Index(" + index + ")"
+			if container.Kind == types.Map {
+				index = fmt.Sprintf("k%d", len(loops))
+				element = ".Key(" + index + ")"
+				if container.Key.Kind == types.Alias {
+					element = ".Key(string(" + index + "))"
+				}
+			}
+			loops = append(loops, validationLoop{guard: strings.TrimSuffix(nilGuard, " && "), index: index, container: accessor})
+			fieldPath, names = childPath(fieldPath, names)+element, nil
+			accessor, nilGuard = accessor+"["+index+"]", ""
+			if container.Elem.Kind == types.Pointer {
+				nilGuard = accessor + " != nil && "
+			}
+		}
+
+		accessor += "." + chain[i].Name
+		if offset+i >= 0 {
+			names = append(names, v.literal(strconv.Quote(path[offset+i])))
+		}
+	}
+	fieldPath = childPath(fieldPath, names)
+
+	start := len(v.rules)
+	v.addMemberRules(member, validation, accessor, nilGuard, fieldPath)
+	v.rules = append(v.rules[:start], wrapInLoops(v.rules[start:], loops)...)
+	return nil
+}
+
+// validationLoop iterates the elements of a slice or map while guard, if any, holds.
+type validationLoop struct {
+	guard     string
+	index     string
+	container string
+}
+
+// childPath appends the field names to the field path expression, which is created if empty.
+func childPath(fieldPath string, names []string) string {
+	switch {
+	case fieldPath == "":
+		return fmt.Sprintf("$.newPath|raw$(%s)", strings.Join(names, ", "))
+	case len(names) > 0:
+		return fmt.Sprintf("%s.Child(%s)", fieldPath, strings.Join(names, ", "))
+	}
+	return fieldPath
+}
+
+// wrapInLoops nests the rules in the loops, the first loop being the outermost.
+func wrapInLoops(rules []string, loops []validationLoop) []string {
+	if len(rules) == 0 || len(loops) == 0 {
+		return rules
+	}
+
+	body := strings.Join(rules, "")
+	for i := len(loops) - 1; i >= 0; i-- {
+		body = fmt.Sprintf("\tfor %s := range %s {\n%s\t}\n", loops[i].index, loops[i].container, indent(body))
+		if loops[i].guard != "" {
+			body = fmt.Sprintf("\tif %s {\n%s\t}\n", loops[i].guard, indent(body))
+		}
+	}
+	return []string{body}
+}
+
+func indent(in string) string {
+	lines := strings.SplitAfter(in, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "\t" + line
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// addMemberRules records the rules of the member read by accessor, guarded by nilGuard.
+func (v *Validator) addMemberRules(member types.Member, validation tags.Validation, accessor string, nilGuard string, fieldPath string) {
+	value, guard := accessor, nilGuard
+	memberType := member.Type
+	if memberType.Kind == types.Pointer {
+		value, guard = "*"+accessor, nilGuard+accessor+" != nil && "
+		memberType = memberType.Elem
+	} else if zero := zeroValue(memberType); zero != "" && !validation.Required && tags.IsMemberOptional(member) {
+		guard = nilGuard + accessor + " != " + zero + " && "
+	}
+
+	if validation.Required {
+		if check := requiredCheck(accessor, member.Type); check != "" {
+			v.addRule(nilGuard+check, fmt.Sprintf(`$.required|raw$(%s, "")`, fieldPath))
+		}
+	}
+
+	if !isBuiltinOrAlias(memberType) {
+		return
+	}
+
+	if validation.Minimum != "" {
+		v.addRule(fmt.Sprintf("%s%s < %s", guard, value, validation.Minimum),
+			fmt.Sprintf("$.invalid|raw$(%s, %s, %s)", fieldPath, value, v.literal(strconv.Quote("must be greater than or equal to "+validation.Minimum))))
+	}
+
+	if validation.Maximum != "" {
+		v.addRule(fmt.Sprintf("%s%s > %s", guard, value, validation.Maximum),
+			fmt.Sprintf("$.invalid|raw$(%s, %s, %s)", fieldPath, value, v.literal(strconv.Quote("must be less than or equal to "+validation.Maximum))))
+	}
+
+	if !isStringKind(memberType) {
+		return
+	}
+
+	if validation.MinLength != "" {
+		v.addRule(fmt.Sprintf("%slen(%s) < %s", guard, value, validation.MinLength),
+			fmt.Sprintf("$.invalid|raw$(%s, %s, %s)", fieldPath, value, v.literal(strconv.Quote("must be at least "+validation.MinLength+" characters long"))))
+	}
+
+	if validation.Pattern != "" {
+		v.patterns = append(v.patterns, v.literal(strconv.Quote(validation.Pattern)))
+		pattern := fmt.Sprintf("validate$.type|raw$Pattern%d", len(v.patterns)-1)
+		v.addRule(fmt.Sprintf("%s!%s.MatchString(string(%s))", guard, pattern, value),
+			fmt.Sprintf("$.invalid|raw$(%s, %s, %s)", fieldPath, value, v.literal(strconv.Quote("must match the pattern "+validation.Pattern))))
+	}
+
+	if len(validation.Enum) > 0 {
+		quoted := make([]string, 0, len(validation.Enum))
+		for _, e := range validation.Enum {
+			quoted = append(quoted, v.literal(strconv.Quote(e)))
+		}
+		values := strings.Join(quoted, ", ")
+		v.addRule(fmt.Sprintf("%s!containsString([]string{%s}, string(%s))", guard, values, value),
+			fmt.Sprintf("$.notSupported|raw$(%s, %s, []string{%s})", fieldPath, value, values))
+	}
+}
+
+func (v *Validator) GenerateValidate() (string, generator.Args) {
+	args := generator.Args{
+		"type":         v.Root,
+		"literals":     v.literals,
+		"errorList":    types.Ref(fieldPackage, "ErrorList"),
+		"newPath":      types.Ref(fieldPackage, "NewPath"),
+		"required":     types.Ref(fieldPackage, "Required"),
+		"invalid":      types.Ref(fieldPackage, "Invalid"),
+		"notSupported": types.Ref(fieldPackage, "NotSupported"),
+	}
+
+	if len(v.rules) == 0 {
+		raw := `// Validate is an autogenerated function
+func (o *$.type|raw$) Validate() error {
+	return nil
+}
+
+`
+		return named("Validate", raw, args)
+	}
+
+	raw := ""
+	for i, p := range v.patterns {
+		raw += fmt.Sprintf("var validate$.type|raw$Pattern%d = regexp.MustCompile(%s)\n\n", i, p)
+	}
+
+	raw += `// Validate is an autogenerated function that checks the kubebuilder validation markers of each member.
+func (o *$.type|raw$) Validate() error {
+	allErrs := $.errorList|raw${}
+`
+	for _, r := range v.rules {
+		raw += r
+	}
+	raw += `	return allErrs.ToAggregate()
+}
+
+`
+	return named("Validate", raw, args)
+}
+
+// AddOwnerReferences records the rules of an OwnerReferences member reachable from Root through parent:
+// every reference names its kind and at most one reference is a controller.
+func (v *Validator) AddOwnerReferences(parent *types.Type, member types.Member, path ...string) {
+	accessor := "o." + memberAccessor(v.Root, parent, member)
+
+	quotedPath := make([]string, 0, len(path))
+	for _, p := range path {
+		quotedPath = append(quotedPath, v.literal(strconv.Quote(p)))
+	}
+	fieldPath := fmt.Sprintf("$.newPath|raw$(%s)", strings.Join(quotedPath, ", "))
+
+	v.rules = append(v.rules, fmt.Sprintf(`	controllers := 0
+	for i, ref := range %[1]s {
+		if ref.APIVersion == "" || ref.Kind == "" {
+			allErrs = append(allErrs, $.required|raw$(%[2]s.Index(i), "apiVersion and kind are required"))
+		}
+		if ref.Controller != nil && *ref.Controller {
+			controllers++
+		}
+	}
+	if controllers > 1 {
+		allErrs = append(allErrs, $.invalid|raw$(%[2]s, controllers, "only one reference can be a controller"))
+	}
+`, accessor, fieldPath))
+}
+
+func (v *Validator) addRule(condition string, fieldError string) {
+	v.rules = append(v.rules, fmt.Sprintf("\tif %s {\n\t\tallErrs = append(allErrs, %s)\n\t}\n", condition, fieldError))
+}
+
+// literal stores in as a template argument so that user supplied values never collide with the template delimiters.
+func (v *Validator) literal(in string) string {
+	v.literals = append(v.literals, in)
+	return fmt.Sprintf("$index .literals %d$", len(v.literals)-1)
+}
+
+// checkBound returns an error if the value of a Minimum or Maximum marker is not a number the member can be compared with.
+func checkBound(member types.Member, marker string, bound string) error {
+	if bound == "" {
+		return nil
+	}
+
+	t := member.Type
+	if t.Kind == types.Pointer {
+		t = t.Elem
+	}
+	if t.Kind == types.Alias {
+		t = t.Underlying
+	}
+
+	// byte and rune are aliases sized like uint8 and int32
+	name := strings.NewReplacer("byte", "uint8", "rune", "int32").Replace(t.Name.Name)
+	var err error
+	switch {
+	case !isNumeric(t):
+		return fmt.Errorf("member %s: %s=%s requires a numeric member, not %s", member.Name, marker, bound, member.Type)
+	case isFloat(t):
+		if _, err = strconv.ParseFloat(bound, bitSize(name, "float")); err != nil && !errors.Is(err, strconv.ErrRange) {
+			return fmt.Errorf("member %s: %s=%s is not a number", member.Name, marker, bound)
+		}
+	case strings.HasPrefix(name, "uint"):
+		if _, err = strconv.ParseInt(bound, 10, 64); err != nil && !errors.Is(err, strconv.ErrRange) {
+			return fmt.Errorf("member %s: %s=%s is not an integer", member.Name, marker, bound)
+		}
+		_, err = strconv.ParseUint(bound, 10, bitSize(name, "uint"))
+	default:
+		if _, err = strconv.ParseInt(bound, 10, bitSize(name, "int")); err != nil && !errors.Is(err, strconv.ErrRange) {
+			return fmt.Errorf("member %s: %s=%s is not an integer", member.Name, marker, bound)
+		}
+	}
+	// the generated comparison would not compile with a constant overflowing the member type
+	if err != nil {
+		return fmt.Errorf("member %s: %s=%s is out of range for %s", member.Name, marker, bound, member.Type)
+	}
+	return nil
+}
+
+// checkMinLength returns an error if the value of a MinLength marker is not a non-negative integer.
+func checkMinLength(member types.Member, minLength string) error {
+	if minLength == "" {
+		return nil
+	}
+	if n, err := strconv.Atoi(minLength); err != nil || n < 0 {
+		return fmt.Errorf("member %s: %s=%s is not a non-negative integer", member.Name, tags.ValidationMinLength, minLength)
+	}
+	return nil
+}
+
+func isNumeric(t *types.Type) bool {
+	switch t.Name.Name {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte", "rune", "float32", "float64":
+		return t.Kind == types.Builtin
+	}
+	return false
+}
+
+func isFloat(t *types.Type) bool {
+	return t.Name.Name == "float32" || t.Name.Name == "float64"
+}
+
+func requiredCheck(accessor string, t *types.Type) string {
+	switch {
+	case t.Kind == types.Pointer:
+		return accessor + " == nil"
+	case t.Kind == types.Slice || t.Kind == types.Map:
+		return "len(" + accessor + ") == 0"
+	case isStringKind(t):
+		return accessor + ` == ""`
+	}
+	return ""
+}
+
+// zeroValue returns the zero value literal of a string or numeric type, empty for any other type.
+func zeroValue(t *types.Type) string {
+	if !isBuiltinOrAlias(t) {
+		return ""
+	}
+	if t.Kind == types.Alias {
+		t = t.Underlying
+	}
+	switch {
+	case t == types.String:
+		return `""`
+	case t == types.Bool:
+		return ""
+	}
+	return "0"
+}
+
+func isBuiltinOrAlias(t *types.Type) bool {
+	return t.Kind == types.Builtin || (t.Kind == types.Alias && t.Underlying != nil && t.Underlying.Kind == types.Builtin)
+}
+
+func isStringKind(t *types.Type) bool {
+	if t.Kind == types.Alias {
+		t = t.Underlying
+	}
+	return t == types.String
+}
@@ -0,0 +1,327 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+func TestGenerateValidate(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	validatedStruct := newTestType(t, "ValidatedStruct")
+	parent := getMemberFromType(t, validatedStruct, "ValidatedStruct").Type
+
+	validator := NewValidator(validatedStruct)
+	for _, name := range []string{"Replicas", "Name", "Policy", "Items", "Port", "MinReplicas"} {
+		member := getMemberFromType(t, validatedStruct, "ValidatedStruct", name)
+		require.NoError(t, validator.AddMember(parent, nil, member, tags.ExtractValidation(member), "spec", name))
+	}
+
+	want := `var validateValidatedStructPattern0 = regexp.MustCompile("^[a-z]+$")
+
+// Validate is an autogenerated function that checks the kubebuilder validation markers of each member.
+func (o *ValidatedStruct) Validate() error {
+	allErrs := field.ErrorList{}
+	if o.ValidatedStruct.Replicas != nil && *o.ValidatedStruct.Replicas < 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "Replicas"), *o.ValidatedStruct.Replicas, "must be greater than or equal to 1"))
+	}
+	if o.ValidatedStruct.Replicas != nil && *o.ValidatedStruct.Replicas > 10 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "Replicas"), *o.ValidatedStruct.Replicas, "must be less than or equal to 10"))
+	}
+	if o.ValidatedStruct.Name == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "Name"), ""))
+	}
+	if len(o.ValidatedStruct.Name) < 3 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "Name"), o.ValidatedStruct.Name, "must be at least 3 characters long"))
+	}
+	if !validateValidatedStructPattern0.MatchString(string(o.ValidatedStruct.Name)) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "Name"), o.ValidatedStruct.Name, "must match the pattern ^[a-z]+$"))
+	}
+	if o.ValidatedStruct.Policy != "" && !containsString([]string{"Always", "Never"}, string(o.ValidatedStruct.Policy)) {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "Policy"), o.ValidatedStruct.Policy, []string{"Always", "Never"}))
+	}
+	if len(o.ValidatedStruct.Items) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "Items"), ""))
+	}
+	if o.ValidatedStruct.Port != 0 && o.ValidatedStruct.Port < 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "Port"), o.ValidatedStruct.Port, "must be greater than or equal to 1"))
+	}
+	if o.ValidatedStruct.MinReplicas < 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "MinReplicas"), o.ValidatedStruct.MinReplicas, "must be greater than or equal to 1"))
+	}
+	return allErrs.ToAggregate()
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(validator.GenerateValidate())
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestGenerateValidateNestedMembers(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	nestedStruct := newTestType(t, "NestedStruct")
+	parent := getMemberFromType(t, nestedStruct, "NestedStruct").Type
+	via := []types.Member{
+		getMemberFromType(t, nestedStruct, "NestedStruct", "Spec"),
+		getMemberFromType(t, nestedStruct, "NestedStruct", "Spec", "Template"),
+	}
+
+	validator := NewValidator(nestedStruct)
+	for _, name := range []string{"Name", "Replicas"} {
+		member := getMemberFromType(t, via[1].Type.Elem, name)
+		require.NoError(t, validator.AddMember(parent, via, member, tags.ExtractValidation(member), "spec", "template", name))
+	}
+
+	want := `// Validate is an autogenerated function that checks the kubebuilder validation markers of each member.
+func (o *NestedStruct) Validate() error {
+	allErrs := field.ErrorList{}
+	if o.NestedStruct.Spec.Template != nil && o.NestedStruct.Spec.Template.Name == "" {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "template", "Name"), ""))
+	}
+	if o.NestedStruct.Spec.Template != nil && o.NestedStruct.Spec.Template.Replicas != nil && *o.NestedStruct.Spec.Template.Replicas > 10 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "template", "Replicas"), *o.NestedStruct.Spec.Template.Replicas, "must be less than or equal to 10"))
+	}
+	return allErrs.ToAggregate()
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(validator.GenerateValidate())
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestGenerateValidateElementsOfSlicesAndMaps(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	nestedStruct := newTestType(t, "NestedStruct")
+	parent := getMemberFromType(t, nestedStruct, "NestedStruct").Type
+	spec := getMemberFromType(t, nestedStruct, "NestedStruct", "Spec")
+	template := getMemberFromType(t, nestedStruct, "NestedStruct", "Spec", "Template")
+	templates := getMemberFromType(t, nestedStruct, "NestedStruct", "Spec", "Templates")
+	ports := getMemberFromType(t, template.Type.Elem, "Ports")
+	name := getMemberFromType(t, template.Type.Elem, "Name")
+	port := getMemberFromType(t, ports.Type.Elem, "Port")
+
+	validator := NewValidator(nestedStruct)
+	require.NoError(t, validator.AddMember(parent, []types.Member{spec, template, ports}, port, tags.ExtractValidation(port), "spec", "template", "ports", "port"))
+	require.NoError(t, validator.AddMember(parent, []types.Member{spec, templates}, name, tags.ExtractValidation(name), "spec", "templates", "name"))
+	require.NoError(t, validator.AddMember(parent, []types.Member{spec, templates, ports}, port, tags.ExtractValidation(port), "spec", "templates", "ports", "port"))
+
+	want := `// Validate is an autogenerated function that checks the kubebuilder validation markers of each member.
+func (o *NestedStruct) Validate() error {
+	allErrs := field.ErrorList{}
+	if o.NestedStruct.Spec.Template != nil {
+		for i0 := range o.NestedStruct.Spec.Template.Ports {
+			if o.NestedStruct.Spec.Template.Ports[i0].Port > 65535 {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "template", "ports").Index(i0).Child("port"), o.NestedStruct.Spec.Template.Ports[i0].Port, "must be less than or equal to 65535"))
+			}
+		}
+	}
+	for k0 := range o.NestedStruct.Spec.Templates {
+		if o.NestedStruct.Spec.Templates[k0] != nil && o.NestedStruct.Spec.Templates[k0].Name == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec", "templates").Key(k0).Child("name"), ""))
+		}
+	}
+	for k0 := range o.NestedStruct.Spec.Templates {
+		if o.NestedStruct.Spec.Templates[k0] != nil {
+			for i1 := range o.NestedStruct.Spec.Templates[k0].Ports {
+				if o.NestedStruct.Spec.Templates[k0].Ports[i1].Port > 65535 {
+					allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "templates").Key(k0).Child("ports").Index(i1).Child("port"), o.NestedStruct.Spec.Templates[k0].Ports[i1].Port, "must be less than or equal to 65535"))
+				}
+			}
+		}
+	}
+	return allErrs.ToAggregate()
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(validator.GenerateValidate())
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestValidatorRejectsInvalidPattern(t *testing.T) {
+	t.Parallel()
+
+	validatedStruct := newTestType(t, "ValidatedStruct")
+	parent := getMemberFromType(t, validatedStruct, "ValidatedStruct").Type
+	member := getMemberFromType(t, validatedStruct, "ValidatedStruct", "Invalid")
+
+	err := NewValidator(validatedStruct).AddMember(parent, nil, member, tags.ExtractValidation(member))
+	assert.ErrorContains(t, err, "invalid pattern")
+}
+
+func TestValidatorRejectsInvalidBounds(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		description string
+		member      string
+		validation  tags.Validation
+		wantErr     string
+	}{
+		{
+			description: "minimum on a string",
+			member:      "Name",
+			validation:  tags.Validation{Minimum: "1"},
+			wantErr:     "requires a numeric member",
+		},
+		{
+			description: "minimum is not a number",
+			member:      "Replicas",
+			validation:  tags.Validation{Minimum: "one"},
+			wantErr:     "is not an integer",
+		},
+		{
+			description: "maximum is not an integer",
+			member:      "Port",
+			validation:  tags.Validation{Maximum: "1.5"},
+			wantErr:     "is not an integer",
+		},
+		{
+			description: "min length is not an integer",
+			member:      "Name",
+			validation:  tags.Validation{MinLength: "3.5"},
+			wantErr:     "is not a non-negative integer",
+		},
+		{
+			description: "min length is negative",
+			member:      "Name",
+			validation:  tags.Validation{MinLength: "-1"},
+			wantErr:     "is not a non-negative integer",
+		},
+		{
+			description: "valid min length",
+			member:      "Name",
+			validation:  tags.Validation{MinLength: "3"},
+		},
+		{
+			description: "valid bounds",
+			member:      "Replicas",
+			validation:  tags.Validation{Minimum: "-1", Maximum: "10"},
+		},
+		{
+			description: "negative minimum on an unsigned member",
+			member:      "Weight",
+			validation:  tags.Validation{Minimum: "-1"},
+			wantErr:     "member Weight: kubebuilder:validation:Minimum=-1 is out of range for byte",
+		},
+		{
+			description: "maximum overflows an unsigned member",
+			member:      "Weight",
+			validation:  tags.Validation{Maximum: "256"},
+			wantErr:     "member Weight: kubebuilder:validation:Maximum=256 is out of range for byte",
+		},
+		{
+			description: "maximum overflows a signed member",
+			member:      "Port",
+			validation:  tags.Validation{Maximum: "2147483648"},
+			wantErr:     "member Port: kubebuilder:validation:Maximum=2147483648 is out of range for int32",
+		},
+		{
+			description: "maximum overflows a float member",
+			member:      "Ratio",
+			validation:  tags.Validation{Maximum: "1e39"},
+			wantErr:     "member Ratio: kubebuilder:validation:Maximum=1e39 is out of range for float32",
+		},
+		{
+			description: "unsigned bounds",
+			member:      "Weight",
+			validation:  tags.Validation{Minimum: "0", Maximum: "255"},
+		},
+	}
+
+	validatedStruct := newTestType(t, "ValidatedStruct")
+	parent := getMemberFromType(t, validatedStruct, "ValidatedStruct").Type
+
+	for _, test := range tests {
+		member := getMemberFromType(t, validatedStruct, "ValidatedStruct", test.member)
+		err := NewValidator(validatedStruct).AddMember(parent, nil, member, test.validation)
+		if test.wantErr != "" {
+			assert.ErrorContains(t, err, test.wantErr, test.description)
+		} else {
+			assert.NoError(t, err, test.description)
+		}
+	}
+}
+
+func TestGenerateValidateWithoutRules(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	want := `// Validate is an autogenerated function
+func (o *SomeStruct) Validate() error {
+	return nil
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(NewValidator(newTestType(t, "SomeStruct")).GenerateValidate())
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
+
+func TestGenerateValidateOwnerReferences(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta").Type
+	member := getMemberFromType(t, someStruct, "SomeStruct", "ObjectMeta", "OwnerReferences")
+
+	validator := NewValidator(someStruct)
+	validator.AddOwnerReferences(parent, member, "metadata", "ownerReferences")
+
+	want := `// Validate is an autogenerated function that checks the kubebuilder validation markers of each member.
+func (o *SomeStruct) Validate() error {
+	allErrs := field.ErrorList{}
+	controllers := 0
+	for i, ref := range o.ObjectMeta.OwnerReferences {
+		if ref.APIVersion == "" || ref.Kind == "" {
+			allErrs = append(allErrs, field.Required(field.NewPath("metadata", "ownerReferences").Index(i), "apiVersion and kind are required"))
+		}
+		if ref.Controller != nil && *ref.Controller {
+			controllers++
+		}
+	}
+	if controllers > 1 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "ownerReferences"), controllers, "only one reference can be a controller"))
+	}
+	return allErrs.ToAggregate()
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(validator.GenerateValidate())
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
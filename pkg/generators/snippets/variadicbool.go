@@ -1,6 +1,8 @@
 package snippets
 
-func GenerateVariadicBool() string {
+import "k8s.io/gengo/generator"
+
+func GenerateVariadicBool() (string, generator.Args) {
 	raw := `// variadicBool selects the first element in the passed in list if non-empty. Otherwise the default return is "true".
 func variadicBool(in ...bool) bool {
 	if len(in) > 0 {
@@ -10,15 +12,15 @@ func variadicBool(in ...bool) bool {
 }
 
 `
-	return raw
+	return named("VariadicBool", raw, nil)
 }
 
-func GenerateBoolPointer() string {
+func GenerateBoolPointer() (string, generator.Args) {
 	raw := `// boolPointer returns a pointer to a bool.
 func boolPointer(in bool) *bool {
 	return &in
 }
 
 `
-	return raw
+	return named("BoolPointer", raw, nil)
 }
@@ -26,7 +26,7 @@ func variadicBool(in ...bool) bool {
 `
 	var b bytes.Buffer
 	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
-	sw.Do(GenerateVariadicBool(), nil)
+	sw.Do(GenerateVariadicBool())
 	assert.NoError(t, sw.Error())
 	assert.Equal(t, want, b.String())
 }
@@ -45,7 +45,7 @@ func boolPointer(in bool) *bool {
 `
 	var b bytes.Buffer
 	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
-	sw.Do(GenerateBoolPointer(), nil)
+	sw.Do(GenerateBoolPointer())
 	assert.NoError(t, sw.Error())
 	assert.Equal(t, want, b.String())
 }
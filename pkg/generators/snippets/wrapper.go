@@ -0,0 +1,22 @@
+package snippets
+
+import (
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/types"
+)
+
+// GenerateWrapperType declares a builder type embedding the parent type.
+func GenerateWrapperType(t *types.Type, parent *types.Type) (string, generator.Args) {
+	args := generator.Args{
+		"type":   t,
+		"parent": parent,
+	}
+
+	raw := `// $.type|raw$ is an autogenerated builder of $.parent|raw$.
+type $.type|raw$ struct {
+	$.parent|raw$
+}
+
+`
+	return named("WrapperType", raw, args)
+}
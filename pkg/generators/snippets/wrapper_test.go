@@ -0,0 +1,32 @@
+package snippets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/gengo/generator"
+)
+
+func TestGenerateWrapperType(t *testing.T) {
+	t.Parallel()
+
+	ctx, err := newTestGeneratorContext()
+	require.NoError(t, err)
+
+	someStruct := newTestType(t, "SomeStruct")
+	parent := getMemberFromType(t, someStruct, "SomeStruct").Type
+
+	want := `// SomeStruct is an autogenerated builder of a.SomeStruct.
+type SomeStruct struct {
+	a.SomeStruct
+}
+
+`
+	var b bytes.Buffer
+	sw := generator.NewSnippetWriter(&b, ctx, "$", "$")
+	sw.Do(GenerateWrapperType(someStruct, parent))
+	assert.NoError(t, sw.Error())
+	assert.Equal(t, want, b.String())
+}
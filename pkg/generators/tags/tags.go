@@ -1,7 +1,11 @@
 package tags
 
 import (
+	"fmt"
+	"reflect"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"k8s.io/gengo/types"
 )
@@ -13,6 +17,29 @@ const (
 	BuilderOptOut  = "false"
 	EnumFlag       = "enum"
 	RefFlag        = "ref"
+	GettersFlag    = "getters"
+	ImmutableFlag  = "immutable"
+	DeepFlag       = "deep"
+	EnumAuto       = "auto"
+	SkipFlag       = "skip"
+	IncludeFlag    = "include"
+	GVKFlag        = "gvk"
+	GroupName      = "groupName"
+	NamespacedFlag = "namespaced"
+	RequiredFlag   = "required"
+	RequiredAuto   = "auto"
+	Optional       = "optional"
+	GenClient      = "genclient"
+	NonNamespaced  = "genclient:nonNamespaced"
+	StyleFlag      = "style"
+	StyleBuilder   = "builder"
+	StyleOptions   = "options"
+	ApplyConfig    = "applyconfig"
+	RenderFlag     = "render"
+
+	MemberSkip    = "kanopy:builder:skip"
+	MemberInclude = "kanopy:builder:include"
+	MemberName    = "kanopy:builder:name"
 )
 
 func IsPackageTagged(comments []string) bool {
@@ -27,18 +54,131 @@ func IsTypeOptedOut(t *types.Type) bool {
 	return Extract(combineTypeComments(t), Builder) == BuilderOptOut
 }
 
+// IsArgEnabled returns true if the builder tag contains the argument as a bare flag or set to "true".
+func IsArgEnabled(comments []string, arg string) bool {
+	v := ExtractArg(comments, Builder, arg)
+	return v == arg || v == BuilderOptIn
+}
+
+func IsTypeArgEnabled(t *types.Type, arg string) bool {
+	return IsArgEnabled(combineTypeComments(t), arg)
+}
+
 func GetEnumOptions(t *types.Type) []string {
 	val := ExtractArg(combineTypeComments(t), Builder, EnumFlag)
 	return strings.Split(val, ";")
 }
 
+// IsEnumAuto returns true if the enum values are discovered from the constants of the ref type.
+func IsEnumAuto(t *types.Type) bool {
+	return ExtractArg(combineTypeComments(t), Builder, EnumFlag) == EnumAuto
+}
+
+// ExtractGVK returns the group/version/Kind argument of the type.
+func ExtractGVK(t *types.Type) string {
+	return ExtractArg(combineTypeComments(t), Builder, GVKFlag)
+}
+
+// ExtractStyle returns the style argument of the builder tag, empty if absent.
+func ExtractStyle(comments []string) string {
+	return ExtractArg(comments, Builder, StyleFlag)
+}
+
+// ExtractTypeStyle returns the style argument of the type.
+func ExtractTypeStyle(t *types.Type) string {
+	return ExtractStyle(combineTypeComments(t))
+}
+
+// ExtractNamespaced returns the namespaced argument of the type, the second result reports whether it is present.
+// The argument is either a bare flag or set to true or false.
+func ExtractNamespaced(t *types.Type) (bool, bool, error) {
+	v, bare, found := lookupArg(combineTypeComments(t), Builder, NamespacedFlag)
+	switch {
+	case !found:
+		return false, false, nil
+	case bare || v == BuilderOptIn:
+		return true, true, nil
+	case v == BuilderOptOut:
+		return false, true, nil
+	}
+	return false, true, fmt.Errorf("type %s: %s=%s must be %s or %s", t.Name, NamespacedFlag, v, BuilderOptIn, BuilderOptOut)
+}
+
+// IsGenClientNamespaced returns true if the upstream type is tagged +genclient without +genclient:nonNamespaced.
+func IsGenClientNamespaced(t *types.Type) bool {
+	values := types.ExtractCommentTags("+", combineTypeComments(t))
+	_, client := values[GenClient]
+	_, nonNamespaced := values[NonNamespaced]
+	return client && !nonNamespaced
+}
+
+// ExtractGroupName returns the API group of the package from its +groupName doc tag.
+// The group of the core API is empty, so the second result reports whether the tag is present.
+func ExtractGroupName(pkg *types.Package) (string, bool) {
+	return ExtractGroupNameFromComments(append(pkg.DocComments, pkg.Comments...))
+}
+
+// ExtractGroupNameFromComments returns the API group from a +groupName tag of the comment lines.
+func ExtractGroupNameFromComments(comments []string) (string, bool) {
+	values, ok := types.ExtractCommentTags("+", comments)[GroupName]
+	if !ok || len(values) == 0 {
+		return "", false
+	}
+	return values[0], true
+}
+
 func ExtractRef(t *types.Type) string {
 	return ExtractArg(combineTypeComments(t), Builder, RefFlag)
 }
 
-func IsMemberReadyOnly(m types.Member) bool {
-	for _, s := range m.CommentLines {
-		if strings.Contains(strings.ToLower(s), "read-only") {
+// ExtractTypeArg returns the value of a type argument, the argument itself for a bare flag and empty if absent.
+func ExtractTypeArg(t *types.Type, arg string) string {
+	return ExtractArg(combineTypeComments(t), Builder, arg)
+}
+
+// GetTypeArgList returns the ";" separated values of a type argument, e.g. skip=Field1;Field2.
+func GetTypeArgList(t *types.Type, arg string) []string {
+	val := ExtractArg(combineTypeComments(t), Builder, arg)
+	if val == "" || val == arg {
+		return nil
+	}
+	return strings.Split(val, ";")
+}
+
+// IsMemberSkipped returns true if the member is marked with +kanopy:builder:skip.
+func IsMemberSkipped(m types.Member) bool {
+	_, ok := types.ExtractCommentTags("+", m.CommentLines)[MemberSkip]
+	return ok
+}
+
+// IsMemberIncluded returns true if the member is marked with +kanopy:builder:include.
+func IsMemberIncluded(m types.Member) bool {
+	_, ok := types.ExtractCommentTags("+", m.CommentLines)[MemberInclude]
+	return ok
+}
+
+// ExtractMemberName returns the name of the member used in generated function names from +kanopy:builder:name.
+// A leading With or Append verb followed by an upper case letter is removed so that name=WithImageRef renames every
+// function of the member, e.g. GetImageRef, while name=WithdrawalLimit is kept as is.
+func ExtractMemberName(m types.Member) string {
+	name := Extract(m.CommentLines, MemberName)
+	for _, verb := range []string{"With", "Append"} {
+		if trimmed := strings.TrimPrefix(name, verb); trimmed != name && trimmed != "" {
+			if r, _ := utf8.DecodeRuneInString(trimmed); unicode.IsUpper(r) {
+				return trimmed
+			}
+		}
+	}
+	return name
+}
+
+// IsMemberOptional returns true if the member is marked +optional or serialized with omitempty.
+func IsMemberOptional(m types.Member) bool {
+	if _, ok := types.ExtractCommentTags("+", m.CommentLines)[Optional]; ok {
+		return true
+	}
+	for _, option := range strings.Split(reflect.StructTag(m.Tags).Get("json"), ",")[1:] {
+		if option == "omitempty" {
 			return true
 		}
 	}
@@ -55,28 +195,31 @@ func Extract(comments []string, tag string) string {
 }
 
 func ExtractArg(comments []string, tag string, arg string) string {
+	v, bare, _ := lookupArg(comments, tag, arg)
+	if bare {
+		return arg
+	}
+	return v
+}
+
+// lookupArg returns the value of the argument of the tag, whether it is a bare flag and whether it is present.
+func lookupArg(comments []string, tag string, arg string) (string, bool, bool) {
 	vals := types.ExtractCommentTags("+", comments)[tag]
 	if len(vals) == 0 {
-		return ""
+		return "", false, false
 	}
 
 	args := strings.Split(vals[0], ",")
 	for _, a := range args {
-		if strings.Contains(a, "=") {
-			kvs := strings.Split(a, "=")
-			if len(kvs) == 2 {
-				key := kvs[0]
-				if key == arg {
-					return kvs[1]
-				}
-			}
+		if key, value, found := strings.Cut(a, "="); found && key == arg {
+			return value, false, true
 		}
 
 		if a == arg {
-			return a
+			return "", true, true
 		}
 	}
-	return ""
+	return "", false, false
 }
 
 func combineTypeComments(t *types.Type) []string {
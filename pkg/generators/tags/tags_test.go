@@ -75,6 +75,12 @@ func TestExtractEnumArgFromCommentTag(t *testing.T) {
 			comments:    []string{fmt.Sprintf(fmtTag, Builder, "value", "val1;val2")},
 			want:        "val1;val2",
 		},
+		{
+			description: "named enum values from comments",
+			tag:         Builder,
+			comments:    []string{fmt.Sprintf(fmtTag, Builder, "value", "Pending=0;Running=1")},
+			want:        "Pending=0;Running=1",
+		},
 	}
 
 	for _, test := range tests {
@@ -113,6 +119,33 @@ func TestGetEnumOptions(t *testing.T) {
 	}
 }
 
+func TestIsEnumAuto(t *testing.T) {
+	tests := []struct {
+		description string
+		comments    []string
+		want        bool
+	}{
+		{
+			description: "auto enum",
+			comments:    []string{"+kanopy:builder=true,ref=k8s.io/api/core/v1.PullPolicy,enum=auto"},
+			want:        true,
+		},
+		{
+			description: "listed enum",
+			comments:    []string{"+kanopy:builder=true,enum=Always;Never"},
+		},
+		{
+			description: "no enum",
+			comments:    []string{"+kanopy:builder=true"},
+		},
+	}
+
+	for _, test := range tests {
+		tt := types.Type{CommentLines: test.comments}
+		assert.Equal(t, test.want, IsEnumAuto(&tt), test.description)
+	}
+}
+
 func TestExtractRef(t *testing.T) {
 	fmtTag := "+%s=%s,ref=%s"
 	tests := []struct {
@@ -138,6 +171,260 @@ func TestExtractRef(t *testing.T) {
 	}
 }
 
+func TestIsArgEnabled(t *testing.T) {
+	tests := []struct {
+		description string
+		comments    []string
+		want        bool
+	}{
+		{
+			description: "argument set to true",
+			comments:    []string{fmt.Sprintf("+%s=true,%s=true", Builder, GettersFlag)},
+			want:        true,
+		},
+		{
+			description: "bare argument",
+			comments:    []string{fmt.Sprintf("+%s=package,%s", Builder, GettersFlag)},
+			want:        true,
+		},
+		{
+			description: "argument set to false",
+			comments:    []string{fmt.Sprintf("+%s=true,%s=false", Builder, GettersFlag)},
+		},
+		{
+			description: "argument missing",
+			comments:    []string{fmt.Sprintf("+%s=true", Builder)},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, IsArgEnabled(test.comments, GettersFlag), test.description)
+		assert.Equal(t, test.want, IsTypeArgEnabled(&types.Type{CommentLines: test.comments}, GettersFlag), test.description)
+	}
+}
+
+func TestGetTypeArgList(t *testing.T) {
+	tests := []struct {
+		description string
+		comments    []string
+		want        []string
+	}{
+		{
+			description: "list argument",
+			comments:    []string{fmt.Sprintf("+%s=true,%s=Status;ObjectMeta.Labels", Builder, SkipFlag)},
+			want:        []string{"Status", "ObjectMeta.Labels"},
+		},
+		{
+			description: "bare argument",
+			comments:    []string{fmt.Sprintf("+%s=true,%s", Builder, SkipFlag)},
+		},
+		{
+			description: "argument missing",
+			comments:    []string{fmt.Sprintf("+%s=true", Builder)},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, GetTypeArgList(&types.Type{CommentLines: test.comments}, SkipFlag), test.description)
+	}
+}
+
+func TestMemberMarkers(t *testing.T) {
+	tests := []struct {
+		description string
+		comments    []string
+		skipped     bool
+		included    bool
+		name        string
+	}{
+		{
+			description: "skip marker",
+			comments:    []string{"+" + MemberSkip},
+			skipped:     true,
+		},
+		{
+			description: "include marker",
+			comments:    []string{"Read-only.", "+" + MemberInclude},
+			included:    true,
+		},
+		{
+			description: "name marker with verb",
+			comments:    []string{"+" + MemberName + "=WithImageRef"},
+			name:        "ImageRef",
+		},
+		{
+			description: "name marker starting with a verb",
+			comments:    []string{"+" + MemberName + "=WithdrawalLimit"},
+			name:        "WithdrawalLimit",
+		},
+		{
+			description: "name marker starting with append",
+			comments:    []string{"+" + MemberName + "=Appendix"},
+			name:        "Appendix",
+		},
+		{
+			description: "name marker without verb",
+			comments:    []string{"+" + MemberName + "=ImageRef"},
+			name:        "ImageRef",
+		},
+		{
+			description: "no markers",
+			comments:    []string{"a member"},
+		},
+	}
+
+	for _, test := range tests {
+		m := types.Member{CommentLines: test.comments}
+		assert.Equal(t, test.skipped, IsMemberSkipped(m), test.description)
+		assert.Equal(t, test.included, IsMemberIncluded(m), test.description)
+		assert.Equal(t, test.name, ExtractMemberName(m), test.description)
+	}
+}
+
+func TestExtractGroupName(t *testing.T) {
+	tests := []struct {
+		description string
+		pkg         *types.Package
+		want        string
+		wantOk      bool
+	}{
+		{
+			description: "group name in doc.go",
+			pkg:         &types.Package{Comments: []string{"+k8s:deepcopy-gen=package", "+groupName=apps"}},
+			want:        "apps",
+			wantOk:      true,
+		},
+		{
+			description: "empty core group name",
+			pkg:         &types.Package{Comments: []string{"+groupName="}},
+			wantOk:      true,
+		},
+		{
+			description: "no group name",
+			pkg:         &types.Package{DocComments: []string{"Package v1 is ..."}},
+		},
+	}
+
+	for _, test := range tests {
+		group, ok := ExtractGroupName(test.pkg)
+		assert.Equal(t, test.want, group, test.description)
+		assert.Equal(t, test.wantOk, ok, test.description)
+	}
+}
+
+func TestExtractGVK(t *testing.T) {
+	tt := types.Type{CommentLines: []string{fmt.Sprintf("+%s=true,%s=apps/v1/Deployment", Builder, GVKFlag)}}
+	assert.Equal(t, "apps/v1/Deployment", ExtractGVK(&tt))
+}
+
+func TestExtractStyle(t *testing.T) {
+	assert.Equal(t, StyleOptions, ExtractStyle([]string{fmt.Sprintf("+%s=%s,%s=%s", Builder, BuilderPackage, StyleFlag, StyleOptions)}))
+	assert.Equal(t, "", ExtractStyle([]string{fmt.Sprintf("+%s=%s", Builder, BuilderPackage)}))
+
+	tt := types.Type{CommentLines: []string{fmt.Sprintf("+%s=true,%s=%s", Builder, StyleFlag, StyleBuilder)}}
+	assert.Equal(t, StyleBuilder, ExtractTypeStyle(&tt))
+}
+
+func TestNamespaced(t *testing.T) {
+	tests := []struct {
+		description    string
+		comments       []string
+		second         []string
+		wantArg        bool
+		wantArgOk      bool
+		wantErr        bool
+		wantNamespaced bool
+	}{
+		{
+			description: "namespaced argument",
+			comments:    []string{fmt.Sprintf("+%s=true,%s=true", Builder, NamespacedFlag)},
+			wantArg:     true,
+			wantArgOk:   true,
+		},
+		{
+			description: "bare namespaced argument",
+			comments:    []string{fmt.Sprintf("+%s=true,%s", Builder, NamespacedFlag)},
+			wantArg:     true,
+			wantArgOk:   true,
+		},
+		{
+			description: "namespaced argument set to false",
+			comments:    []string{fmt.Sprintf("+%s=true,%s=false", Builder, NamespacedFlag)},
+			wantArgOk:   true,
+		},
+		{
+			description: "namespaced argument set to its name",
+			comments:    []string{fmt.Sprintf("+%s=true,%s=%s", Builder, NamespacedFlag, NamespacedFlag)},
+			wantArgOk:   true,
+			wantErr:     true,
+		},
+		{
+			description: "namespaced argument with a typo",
+			comments:    []string{fmt.Sprintf("+%s=true,%s=ture", Builder, NamespacedFlag)},
+			wantArgOk:   true,
+			wantErr:     true,
+		},
+		{
+			description:    "genclient separated from the doc comment",
+			second:         []string{"+genclient"},
+			comments:       []string{"Deployment enables declarative updates for Pods and ReplicaSets."},
+			wantNamespaced: true,
+		},
+		{
+			description: "genclient nonNamespaced",
+			comments:    []string{"+genclient", "+genclient:nonNamespaced"},
+		},
+		{
+			description: "no genclient",
+			comments:    []string{"DeploymentSpec is the specification of the desired behavior of the Deployment."},
+		},
+	}
+
+	for _, test := range tests {
+		tt := types.Type{CommentLines: test.comments, SecondClosestCommentLines: test.second}
+		namespaced, ok, err := ExtractNamespaced(&tt)
+		if test.wantErr {
+			assert.Error(t, err, test.description)
+		} else {
+			assert.NoError(t, err, test.description)
+		}
+		assert.Equal(t, test.wantArg, namespaced, test.description)
+		assert.Equal(t, test.wantArgOk, ok, test.description)
+		assert.Equal(t, test.wantNamespaced, IsGenClientNamespaced(&tt), test.description)
+	}
+}
+
+func TestIsMemberOptional(t *testing.T) {
+	tests := []struct {
+		description string
+		member      types.Member
+		want        bool
+	}{
+		{
+			description: "optional marker",
+			member:      types.Member{CommentLines: []string{"+optional"}, Tags: `json:"name"`},
+			want:        true,
+		},
+		{
+			description: "omitempty",
+			member:      types.Member{Tags: `json:"image,omitempty"`},
+			want:        true,
+		},
+		{
+			description: "required member",
+			member:      types.Member{Tags: `json:"name" protobuf:"bytes,1,opt,name=name"`},
+		},
+		{
+			description: "no tags",
+			member:      types.Member{},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, IsMemberOptional(test.member), test.description)
+	}
+}
+
 func TestTypeEnabled(t *testing.T) {
 	assert.True(t, IsTypeEnabled(getTestPackage(t).Types["AType"]))
 }
@@ -150,12 +437,6 @@ func TestAllPackageTypes(t *testing.T) {
 	assert.True(t, IsPackageTagged(getTestPackage(t).Comments))
 }
 
-func TestMemberReadyOnly(t *testing.T) {
-	testType := getTestPackage(t).Types["MemberComments"]
-	assert.False(t, IsMemberReadyOnly(getMemberByName(t, testType, "Name")))
-	assert.True(t, IsMemberReadyOnly(getMemberByName(t, testType, "UID")))
-}
-
 func getTestPackage(t *testing.T) *types.Package {
 	testDir := "./testdata/a"
 	d := args.Default()
@@ -179,3 +460,40 @@ func getMemberByName(t *testing.T, inputType *types.Type, name string) types.Mem
 	t.Fatalf("failed to find %q in type %q", name, inputType)
 	return types.Member{}
 }
+
+func TestExtractValidation(t *testing.T) {
+	testType := getTestPackage(t).Types["MemberValidations"]
+
+	tests := []struct {
+		description string
+		member      string
+		want        Validation
+	}{
+		{
+			description: "numeric bounds",
+			member:      "Replicas",
+			want:        Validation{Minimum: "1", Maximum: "10"},
+		},
+		{
+			description: "required string with length and pattern",
+			member:      "Name",
+			want:        Validation{Required: true, MinLength: "3", Pattern: "^[a-z]+$"},
+		},
+		{
+			description: "enum values are unquoted",
+			member:      "Policy",
+			want:        Validation{Enum: []string{"Always", "Never"}},
+		},
+		{
+			description: "no markers",
+			member:      "NoMarkers",
+			want:        Validation{},
+		},
+	}
+
+	for _, test := range tests {
+		v := ExtractValidation(getMemberByName(t, testType, test.member))
+		assert.Equal(t, test.want, v, test.description)
+		assert.Equal(t, test.member == "NoMarkers", v.IsEmpty(), test.description)
+	}
+}
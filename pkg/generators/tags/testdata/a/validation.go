@@ -0,0 +1,17 @@
+package a
+
+type MemberValidations struct {
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	Replicas int32
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:Pattern=`^[a-z]+$`
+	Name string
+
+	// +kubebuilder:validation:Enum=Always;"Never"
+	Policy string
+
+	NoMarkers string
+}
@@ -0,0 +1,68 @@
+package tags
+
+import (
+	"strings"
+
+	"k8s.io/gengo/types"
+)
+
+const (
+	ValidationMinimum   = "kubebuilder:validation:Minimum"
+	ValidationMaximum   = "kubebuilder:validation:Maximum"
+	ValidationPattern   = "kubebuilder:validation:Pattern"
+	ValidationEnum      = "kubebuilder:validation:Enum"
+	ValidationMinLength = "kubebuilder:validation:MinLength"
+	ValidationRequired  = "kubebuilder:validation:Required"
+)
+
+// Validation holds the kubebuilder validation markers found on a member.
+type Validation struct {
+	Minimum   string
+	Maximum   string
+	Pattern   string
+	Enum      []string
+	MinLength string
+	Required  bool
+}
+
+func (v Validation) IsEmpty() bool {
+	return v.Minimum == "" && v.Maximum == "" && v.Pattern == "" && len(v.Enum) == 0 && v.MinLength == "" && !v.Required
+}
+
+func ExtractValidation(m types.Member) Validation {
+	markers := types.ExtractCommentTags("+", m.CommentLines)
+
+	v := Validation{
+		Minimum:   firstMarkerValue(markers, ValidationMinimum),
+		Maximum:   firstMarkerValue(markers, ValidationMaximum),
+		Pattern:   unquote(firstMarkerValue(markers, ValidationPattern)),
+		MinLength: firstMarkerValue(markers, ValidationMinLength),
+	}
+
+	if _, ok := markers[ValidationRequired]; ok {
+		v.Required = true
+	}
+
+	if enum := firstMarkerValue(markers, ValidationEnum); enum != "" {
+		for _, e := range strings.Split(enum, ";") {
+			v.Enum = append(v.Enum, unquote(e))
+		}
+	}
+
+	return v
+}
+
+func firstMarkerValue(markers map[string][]string, marker string) string {
+	vals := markers[marker]
+	if len(vals) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(vals[0])
+}
+
+func unquote(in string) string {
+	if len(in) >= 2 && (in[0] == '"' || in[0] == '`') && in[len(in)-1] == in[0] {
+		return in[1 : len(in)-1]
+	}
+	return in
+}
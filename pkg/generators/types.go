@@ -2,4 +2,6 @@ package generators
 
 type CustomArgs struct {
 	BoundingDirs []string
+	TemplateDir  string
+	MemberConfig string
 }
@@ -0,0 +1,116 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/gengo/namer"
+)
+
+// Config describes the wrapper types to scaffold into a single package.
+type Config struct {
+	// Package is the name of the generated package.
+	Package string `yaml:"package"`
+	// Options are builder tag arguments added to the package tag in doc.go, e.g. getters=true.
+	Options []string `yaml:"options,omitempty"`
+	// Types are the upstream types to wrap.
+	Types []Type `yaml:"types"`
+}
+
+// Type is an upstream type reference, e.g. k8s.io/api/apps/v1.Deployment.
+// A type can be given as a plain string or as a mapping with additional settings.
+type Type struct {
+	// Ref is the fully qualified upstream type.
+	Ref string `yaml:"ref"`
+	// Name overrides the wrapper name, which defaults to the upstream type name.
+	Name string `yaml:"name,omitempty"`
+	// Options are builder tag arguments added to the type tag, e.g. deep=true.
+	Options []string `yaml:"options,omitempty"`
+	// Enum values declare the type as an enum alias instead of a struct wrapper.
+	Enum []string `yaml:"enum,omitempty"`
+}
+
+func (t *Type) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return value.Decode(&t.Ref)
+	}
+
+	type plain Type
+	return value.Decode((*plain)(t))
+}
+
+// LoadConfig reads and validates a scaffold configuration file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Config{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// Validate returns an error if the configuration cannot produce a compilable package.
+func (c *Config) Validate() error {
+	if !token.IsIdentifier(c.Package) {
+		return fmt.Errorf("package %q is not a valid Go identifier", c.Package)
+	}
+
+	if len(c.Types) == 0 {
+		return fmt.Errorf("no types configured")
+	}
+
+	names := map[string]string{}
+	for _, t := range c.Types {
+		pkgPath, typeName := t.split()
+		if pkgPath == "" || namer.IsPrivateGoName(typeName) || !token.IsIdentifier(typeName) {
+			return fmt.Errorf("type %q must be an exported type of the form <import path>.<Type>", t.Ref)
+		}
+
+		name := t.WrapperName()
+		if namer.IsPrivateGoName(name) || !token.IsIdentifier(name) {
+			return fmt.Errorf("name %q of type %q is not an exported Go identifier", name, t.Ref)
+		}
+
+		if ref, ok := names[name]; ok {
+			return fmt.Errorf("types %q and %q both generate %s", ref, t.Ref, name)
+		}
+		names[name] = t.Ref
+
+		for _, v := range t.Enum {
+			if v == "" || strings.ContainsAny(v, ";,") {
+				return fmt.Errorf("enum value %q of type %q must not be empty or contain ';' or ','", v, t.Ref)
+			}
+		}
+	}
+
+	return nil
+}
+
+// WrapperName returns the name of the generated wrapper type.
+func (t Type) WrapperName() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	_, name := t.split()
+	return name
+}
+
+func (t Type) split() (string, string) {
+	i := strings.LastIndex(t.Ref, ".")
+	if i < 0 || i < strings.LastIndex(t.Ref, "/") {
+		return "", t.Ref
+	}
+	return t.Ref[:i], t.Ref[i+1:]
+}
@@ -0,0 +1,194 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kanopy-platform/code-generator/pkg/generators/tags"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DocFileName   = "doc.go"
+	TypesFileName = "types.go"
+)
+
+var docTemplate = template.Must(template.New(DocFileName).Parse(`package {{ .Package }}
+
+// +{{ .Tag }}
+`))
+
+var typesTemplate = template.Must(template.New(TypesFileName).Parse(`package {{ .Package }}
+
+import (
+{{- range .Imports }}
+	{{ .Alias }} "{{ .Path }}"
+{{- end }}
+)
+{{ range .Types }}
+// +{{ .Tag }}
+{{- if .Enum }}
+type {{ .Name }} {{ .Upstream }}
+{{- else }}
+type {{ .Name }} struct {
+	{{ .Upstream }}
+}
+{{- end }}
+{{ end }}`))
+
+type importLine struct {
+	Alias string
+	Path  string
+}
+
+type typeDecl struct {
+	Name     string
+	Upstream string
+	Tag      string
+	Enum     bool
+}
+
+// Render returns the content of each scaffolded file keyed by file name.
+func Render(c *Config) (map[string][]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	aliases := importAliases(c.Types)
+	imports := []importLine{}
+	for path, alias := range aliases {
+		imports = append(imports, importLine{Alias: alias, Path: path})
+	}
+	sort.Slice(imports, func(i, j int) bool { return imports[i].Path < imports[j].Path })
+
+	decls := []typeDecl{}
+	for _, t := range c.Types {
+		pkgPath, typeName := t.split()
+		decl := typeDecl{
+			Name:     t.WrapperName(),
+			Upstream: aliases[pkgPath] + "." + typeName,
+			Tag:      builderTag(tags.BuilderOptIn, t.Options...),
+			Enum:     len(t.Enum) > 0,
+		}
+		if decl.Enum {
+			decl.Tag = builderTag(tags.BuilderOptIn, append([]string{
+				tags.RefFlag + "=" + t.Ref,
+				tags.EnumFlag + "=" + strings.Join(t.Enum, ";"),
+			}, t.Options...)...)
+		}
+		decls = append(decls, decl)
+	}
+
+	files := map[string][]byte{}
+	for name, content := range map[string]struct {
+		tmpl *template.Template
+		data interface{}
+	}{
+		DocFileName: {docTemplate, map[string]interface{}{
+			"Package": c.Package,
+			"Tag":     builderTag(tags.BuilderPackage, c.Options...),
+		}},
+		TypesFileName: {typesTemplate, map[string]interface{}{
+			"Package": c.Package,
+			"Imports": imports,
+			"Types":   decls,
+		}},
+	} {
+		buf := &bytes.Buffer{}
+		if err := content.tmpl.Execute(buf, content.data); err != nil {
+			return nil, err
+		}
+
+		src, err := format.Source(buf.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s: %w", name, err)
+		}
+		files[name] = src
+	}
+
+	return files, nil
+}
+
+// Write renders the configuration into dir. Existing files are only overwritten when force is set.
+func Write(c *Config, dir string, force bool) error {
+	files, err := Render(c)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		for _, name := range []string{DocFileName, TypesFileName} {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("%s already exists, use --force to overwrite", path)
+			}
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, name := range []string{DocFileName, TypesFileName} {
+		path := filepath.Join(dir, name)
+		log.Infof("Writing %s", path)
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func builderTag(value string, args ...string) string {
+	return tags.Builder + "=" + strings.Join(append([]string{value}, args...), ",")
+}
+
+// importAliases names each imported package after its last two path elements, e.g. appsv1 for k8s.io/api/apps/v1,
+// and prepends further parent directories until the alias is unique. Packages listed first get the shortest alias.
+func importAliases(types []Type) map[string]string {
+	paths := []string{}
+	seen := map[string]bool{}
+	for _, t := range types {
+		pkgPath, _ := t.split()
+		if !seen[pkgPath] {
+			seen[pkgPath] = true
+			paths = append(paths, pkgPath)
+		}
+	}
+
+	aliases := map[string]string{}
+	used := map[string]bool{}
+	for _, path := range paths {
+		dirs := strings.Split(path, "/")
+		alias := ""
+		for n := len(dirs) - 2; ; n-- {
+			alias = importAlias(dirs[max(n, 0):])
+			if !used[alias] || n <= 0 {
+				break
+			}
+		}
+		for i := 2; used[alias]; i++ {
+			alias = fmt.Sprintf("%s%d", importAlias(dirs), i)
+		}
+		used[alias] = true
+		aliases[path] = alias
+	}
+	return aliases
+}
+
+func importAlias(dirs []string) string {
+	alias := strings.ToLower(strings.Join(dirs, ""))
+	alias = strings.NewReplacer("_", "", ".", "", "-", "").Replace(alias)
+	if !token.IsIdentifier(alias) {
+		alias = "_" + alias
+	}
+	return alias
+}
@@ -0,0 +1,139 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	c, err := LoadConfig("testdata/scaffold.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "appsv1", c.Package)
+	assert.Equal(t, []string{"getters=true"}, c.Options)
+	assert.Equal(t, Type{Ref: "k8s.io/api/apps/v1.Deployment"}, c.Types[0])
+	assert.Equal(t, Type{Ref: "k8s.io/api/apps/v1.DeploymentSpec", Options: []string{"deep=true"}}, c.Types[1])
+	assert.Equal(t, []string{"Recreate", "RollingUpdate"}, c.Types[2].Enum)
+	assert.Equal(t, "CoreContainer", c.Types[3].WrapperName())
+
+	_, err = LoadConfig("testdata/missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		description string
+		config      Config
+		wantErr     bool
+	}{
+		{
+			description: "Valid config",
+			config:      Config{Package: "v1", Types: []Type{{Ref: "k8s.io/api/apps/v1.Deployment"}}},
+		},
+		{
+			description: "Invalid package name",
+			config:      Config{Package: "apps-v1", Types: []Type{{Ref: "k8s.io/api/apps/v1.Deployment"}}},
+			wantErr:     true,
+		},
+		{
+			description: "No types",
+			config:      Config{Package: "v1"},
+			wantErr:     true,
+		},
+		{
+			description: "Type without package",
+			config:      Config{Package: "v1", Types: []Type{{Ref: "Deployment"}}},
+			wantErr:     true,
+		},
+		{
+			description: "Unexported type",
+			config:      Config{Package: "v1", Types: []Type{{Ref: "k8s.io/api/apps/v1.deployment"}}},
+			wantErr:     true,
+		},
+		{
+			description: "Duplicate wrapper names",
+			config:      Config{Package: "v1", Types: []Type{{Ref: "k8s.io/api/core/v1.Container"}, {Ref: "example.com/v1.Container"}}},
+			wantErr:     true,
+		},
+		{
+			description: "Enum value with separator",
+			config:      Config{Package: "v1", Types: []Type{{Ref: "k8s.io/api/core/v1.Protocol", Enum: []string{"TCP;UDP"}}}},
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		err := test.config.Validate()
+		if test.wantErr {
+			assert.Error(t, err, test.description)
+		} else {
+			assert.NoError(t, err, test.description)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	c, err := LoadConfig("testdata/scaffold.yaml")
+	require.NoError(t, err)
+
+	files, err := Render(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, `package appsv1
+
+// +kanopy:builder=package,getters=true
+`, string(files[DocFileName]))
+
+	assert.Equal(t, `package appsv1
+
+import (
+	apiscorev1 "example.com/apis/core/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// +kanopy:builder=true
+type Deployment struct {
+	appsv1.Deployment
+}
+
+// +kanopy:builder=true,deep=true
+type DeploymentSpec struct {
+	appsv1.DeploymentSpec
+}
+
+// +kanopy:builder=true,ref=k8s.io/api/apps/v1.DeploymentStrategyType,enum=Recreate;RollingUpdate
+type DeploymentStrategyType appsv1.DeploymentStrategyType
+
+// +kanopy:builder=true
+type CoreContainer struct {
+	corev1.Container
+}
+
+// +kanopy:builder=true
+type Container struct {
+	apiscorev1.Container
+}
+`, string(files[TypesFileName]))
+}
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "appsv1")
+	c := &Config{Package: "appsv1", Types: []Type{{Ref: "k8s.io/api/apps/v1.Deployment"}}}
+
+	require.NoError(t, Write(c, dir, false))
+	assert.FileExists(t, filepath.Join(dir, DocFileName))
+	assert.FileExists(t, filepath.Join(dir, TypesFileName))
+
+	assert.Error(t, Write(c, dir, false), "existing files are not overwritten")
+
+	c.Types = append(c.Types, Type{Ref: "k8s.io/api/apps/v1.StatefulSet"})
+	require.NoError(t, Write(c, dir, true))
+	content, err := os.ReadFile(filepath.Join(dir, TypesFileName))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "type StatefulSet struct")
+}